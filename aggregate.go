@@ -0,0 +1,262 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// repoShare is one repository's part of a contributor's commits.
+type repoShare struct {
+	repo    string
+	commits int
+}
+
+// collectRepoInfo gathers credits for one or more repositories. Several
+// repositories are collected concurrently and merged into a single product
+// roll titled by title, or by the repository names joined together when
+// title is empty. Text in the result is passed through sanitizeText, so
+// renderers can draw it as is.
+func collectRepoInfo(ctx context.Context, dirs []string, opts repoOptions, title string) (repoInfo, error) {
+	if len(dirs) <= 1 {
+		dir := ""
+		if len(dirs) == 1 {
+			dir = dirs[0]
+		}
+		info, err := getRepoInfo(ctx, dir, opts)
+		if err != nil {
+			return info, err
+		}
+		if title != "" {
+			info.name = title
+		}
+		return sanitizeRepoInfo(info), nil
+	}
+
+	// the first repository to fail cancels the rest, whose own errors
+	// would only be the cancellation
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	infos := make([]repoInfo, len(dirs))
+	var (
+		wg       sync.WaitGroup
+		failOnce sync.Once
+		firstErr error
+	)
+	for i, dir := range dirs {
+		wg.Add(1)
+		go func(i int, dir string) {
+			defer wg.Done()
+			info, err := getRepoInfo(ctx, dir, opts)
+			if err != nil {
+				failOnce.Do(func() {
+					firstErr = err
+					cancel()
+				})
+				return
+			}
+			infos[i] = info
+		}(i, dir)
+	}
+	wg.Wait()
+	if firstErr != nil {
+		return repoInfo{}, firstErr
+	}
+	return sanitizeRepoInfo(mergeRepoInfos(infos, title, opts)), nil
+}
+
+// mergeRepoInfos combines several repositories' credits. People are unified
+// across repositories by the same identity rules used within one, commit
+// totals and stars are summed, and each contributor keeps a per-repository
+// breakdown.
+func mergeRepoInfos(infos []repoInfo, title string, opts repoOptions) repoInfo {
+	merged := repoInfo{name: title}
+	var names []string
+
+	people := newIdentityResolver()
+	bots := newIdentityResolver()
+	var roleTitles []string
+	roles := make(map[string]*identityResolver)
+	var licenses []string
+	languageBytes := make(map[string]int64)
+	perRepo := make([][]highlight, len(infos))
+	// breakdowns are keyed by directory so same-named repositories stay
+	// apart, and labelled afterwards
+	keys, labels := repoLabels(infos)
+
+	for i, info := range infos {
+		names = append(names, info.name)
+		key, label := keys[i], labels[keys[i]]
+		merged.totalCommits += info.totalCommits
+		merged.stars += info.stars
+		for _, c := range info.contributors {
+			people.addContributor(c, key)
+		}
+		for _, b := range info.bots {
+			bots.addContributor(b, key)
+		}
+		for _, role := range info.roles {
+			r, ok := roles[role.title]
+			if !ok {
+				r = newIdentityResolver()
+				roles[role.title] = r
+				roleTitles = append(roleTitles, role.title)
+			}
+			for _, p := range role.people {
+				r.addContributor(p, key)
+			}
+		}
+		if info.license != "" && !containsString(licenses, info.license) {
+			licenses = append(licenses, info.license)
+		}
+		if merged.language == "" {
+			merged.language = info.language
+		}
+		merged.timeline.merge(info.timeline)
+		for _, l := range info.languages {
+			languageBytes[l.name] += l.bytes
+		}
+		if merged.window == "" {
+			merged.window = info.window
+		}
+		if merged.release == "" {
+			merged.release = info.release
+		}
+		for _, f := range info.failures {
+			f.source = label + " " + f.source
+			merged.failures = append(merged.failures, f)
+		}
+		for _, h := range info.highlights {
+			if h.scope == "" {
+				h.scope = label
+			} else {
+				h.scope = label + ": " + h.scope
+			}
+			perRepo[i] = append(perRepo[i], h)
+		}
+	}
+
+	if merged.name == "" {
+		merged.name = strings.Join(names, " + ")
+	}
+	merged.contributors = relabelRepos(people.contributors(), labels)
+	rankContributors(merged.contributors, opts.rankBy)
+	merged.bots = relabelRepos(bots.contributors(), labels)
+	for _, t := range roleTitles {
+		merged.roles = append(merged.roles, roleCredit{title: t, people: relabelRepos(roles[t].contributors(), labels)})
+	}
+	merged.license = strings.Join(licenses, ", ")
+	if merged.languages = languageShares(languageBytes); len(merged.languages) > 0 {
+		merged.language = merged.languages[0].name
+	}
+
+	// take highlights round-robin so every repository gets a scene
+	for round := 0; len(merged.highlights) < maxHighlights; round++ {
+		added := false
+		for _, hs := range perRepo {
+			if round < len(hs) && len(merged.highlights) < maxHighlights {
+				merged.highlights = append(merged.highlights, hs[round])
+				added = true
+			}
+		}
+		if !added {
+			break
+		}
+	}
+	return merged
+}
+
+// repoLabels returns a key per repository, its directory when known, and
+// the label each key is credited under. Repositories sharing a name are
+// told apart by their parent directory, e.g. "team-a/api".
+func repoLabels(infos []repoInfo) ([]string, map[string]string) {
+	counts := make(map[string]int)
+	for _, info := range infos {
+		counts[info.name]++
+	}
+	keys := make([]string, len(infos))
+	labels := make(map[string]string, len(infos))
+	for i, info := range infos {
+		keys[i] = info.path
+		if keys[i] == "" {
+			keys[i] = info.name
+		}
+		label := info.name
+		if counts[info.name] > 1 && info.path != "" {
+			label = filepath.Base(filepath.Dir(info.path)) + "/" + info.name
+		}
+		labels[keys[i]] = label
+	}
+	return keys, labels
+}
+
+// relabelRepos replaces repository keys in per-repository breakdowns with
+// their labels.
+func relabelRepos(cs []contributor, labels map[string]string) []contributor {
+	for i := range cs {
+		for j := range cs[i].repos {
+			if label, ok := labels[cs[i].repos[j].repo]; ok {
+				cs[i].repos[j].repo = label
+			}
+		}
+	}
+	return cs
+}
+
+// readManifest reads a file listing one repository directory per line.
+// Blank lines and "#" comments are ignored, and relative paths are taken
+// relative to the manifest itself.
+func readManifest(path string) ([]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("open manifest: %w", err)
+	}
+	defer f.Close()
+
+	base := filepath.Dir(path)
+	var dirs []string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if i := strings.Index(line, "#"); i >= 0 {
+			line = strings.TrimSpace(line[:i])
+		}
+		if line == "" {
+			continue
+		}
+		if !filepath.IsAbs(line) {
+			line = filepath.Join(base, line)
+		}
+		dirs = append(dirs, line)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("read manifest: %w", err)
+	}
+	if len(dirs) == 0 {
+		return nil, fmt.Errorf("manifest %q lists no repositories", path)
+	}
+	return dirs, nil
+}
+
+// repoBreakdown renders a contributor's per-repository commits, e.g.
+// "api 120 · web 14", or "" outside aggregated credits.
+func repoBreakdown(c contributor) string {
+	parts := make([]string, 0, len(c.repos))
+	for _, r := range c.repos {
+		parts = append(parts, fmt.Sprintf("%s %d", r.repo, r.commits))
+	}
+	return strings.Join(parts, " · ")
+}
+
+func containsString(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,121 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestMergeRepoInfos(t *testing.T) {
+	api := repoInfo{
+		name:         "api",
+		totalCommits: 10,
+		stars:        5,
+		contributors: []contributor{
+			{name: "Alice", emails: []string{"alice@example.com"}, commits: 8, authored: 8},
+			{name: "Bob", emails: []string{"bob@example.com"}, commits: 2, authored: 2},
+		},
+		highlights: []highlight{{kind: "feat", description: "tokens"}},
+	}
+	web := repoInfo{
+		name:         "web",
+		totalCommits: 6,
+		stars:        3,
+		contributors: []contributor{
+			{name: "alice", emails: []string{"ALICE@example.com"}, commits: 6, authored: 6},
+		},
+		highlights: []highlight{{kind: "fix", scope: "ui", description: "layout"}},
+	}
+
+	merged := mergeRepoInfos([]repoInfo{api, web}, "ACME PLATFORM", repoOptions{})
+	if merged.name != "ACME PLATFORM" || merged.totalCommits != 16 || merged.stars != 8 {
+		t.Fatalf("unexpected totals: %+v", merged)
+	}
+	if len(merged.contributors) != 2 {
+		t.Fatalf("expected Alice unified across repos, got %+v", merged.contributors)
+	}
+	alice := merged.contributors[0]
+	if alice.commits != 14 || repoBreakdown(alice) != "api 8 · web 6" {
+		t.Fatalf("unexpected Alice: %d commits, breakdown %q", alice.commits, repoBreakdown(alice))
+	}
+	if len(merged.highlights) != 2 || merged.highlights[1].scope != "web: ui" {
+		t.Fatalf("expected highlights from both repos, got %+v", merged.highlights)
+	}
+
+	untitled := mergeRepoInfos([]repoInfo{api, web}, "", repoOptions{})
+	if untitled.name != "api + web" {
+		t.Fatalf("expected joined repo names, got %q", untitled.name)
+	}
+}
+
+func TestMergeRepoInfos_SameNamedRepos(t *testing.T) {
+	alice := contributor{name: "Alice", emails: []string{"alice@example.com"}, commits: 3, authored: 3}
+	var hs []highlight
+	for i := 0; i < maxHighlights; i++ {
+		hs = append(hs, highlight{kind: "feat", description: "work"})
+	}
+	first := repoInfo{name: "api", path: "/src/team-a/api", contributors: []contributor{alice}, highlights: hs}
+	alice.commits, alice.authored = 2, 2
+	second := repoInfo{name: "api", path: "/src/team-b/api", contributors: []contributor{alice}, highlights: hs}
+
+	merged := mergeRepoInfos([]repoInfo{first, second}, "", repoOptions{})
+	if len(merged.contributors) != 1 {
+		t.Fatalf("expected one Alice, got %+v", merged.contributors)
+	}
+	if got := repoBreakdown(merged.contributors[0]); got != "team-a/api 3 · team-b/api 2" {
+		t.Fatalf("expected separate breakdowns for same-named repos, got %q", got)
+	}
+	if len(merged.highlights) != maxHighlights {
+		t.Fatalf("expected %d highlights, got %d", maxHighlights, len(merged.highlights))
+	}
+	if merged.highlights[1].scope != "team-b/api" {
+		t.Fatalf("expected highlights labelled by parent directory, got %q", merged.highlights[1].scope)
+	}
+}
+
+func TestReadManifest(t *testing.T) {
+	dir := t.TempDir()
+	manifest := filepath.Join(dir, "repos.txt")
+	content := "# product repos\napi\n\n/abs/web  # frontend\n"
+	if err := os.WriteFile(manifest, []byte(content), 0o644); err != nil {
+		t.Fatalf("write manifest: %v", err)
+	}
+	dirs, err := readManifest(manifest)
+	if err != nil {
+		t.Fatalf("readManifest returned error: %v", err)
+	}
+	want := filepath.Join(dir, "api") + ",/abs/web"
+	if got := strings.Join(dirs, ","); got != want {
+		t.Fatalf("readManifest = %q, want %q", got, want)
+	}
+}
+
+func TestCollectRepoInfo_MultipleRepos(t *testing.T) {
+	first := setupTestRepo(t)
+	second := setupTestRepo(t)
+	commitAs(t, second, "Other Dev", "other@example.com", "feat: second repo work")
+
+	info, err := collectRepoInfo(context.Background(), []string{first, second}, repoOptions{}, "")
+	if err != nil {
+		t.Fatalf("collectRepoInfo returned error: %v", err)
+	}
+	if info.totalCommits != 3 || len(info.contributors) != 2 {
+		t.Fatalf("unexpected aggregate: %d commits, %+v", info.totalCommits, info.contributors)
+	}
+	if info.contributors[0].name != "Test User" || info.contributors[0].commits != 2 {
+		t.Fatalf("expected Test User unified across repos, got %+v", info.contributors[0])
+	}
+}
+
+func TestCollectRepoInfo_FirstErrorWins(t *testing.T) {
+	good := setupTestRepo(t)
+	bad := t.TempDir()
+
+	_, err := collectRepoInfo(context.Background(), []string{good, bad, good}, repoOptions{}, "")
+	if err == nil || errors.Is(err, context.Canceled) || !strings.Contains(err.Error(), bad) {
+		t.Fatalf("expected the failing repository's own error, got %v", err)
+	}
+}
@@ -0,0 +1,78 @@
+package main
+
+import (
+	"path"
+	"strings"
+)
+
+// botModes lists the accepted --bots values.
+var botModes = []string{"hide", "separate", "include"}
+
+func validBotMode(mode string) bool {
+	for _, m := range botModes {
+		if m == mode {
+			return true
+		}
+	}
+	return false
+}
+
+// knownBotPatterns match the lowercased names and emails of common
+// automation accounts. Users add their own with --bot-pattern.
+var knownBotPatterns = []string{
+	`*\[bot\]`,
+	`*\[bot\]@*`,
+	"dependabot*",
+	"renovate*",
+	"github-actions*",
+	"greenkeeper*",
+	"snyk-bot",
+	"pre-commit-ci*",
+	"mergify*",
+	"imgbot*",
+	"allcontributors*",
+	"semantic-release-bot*",
+	"action@github.com",
+	"*@dependabot.com",
+	"bot@renovateapp.com",
+}
+
+// validBotPattern reports whether p is a well-formed glob, so a typo in
+// --bot-pattern is rejected instead of silently matching nothing.
+func validBotPattern(p string) bool {
+	_, err := path.Match(strings.ToLower(p), "")
+	return err == nil
+}
+
+// isBot reports whether any of the contributor's names or emails matches a
+// known or user-supplied glob pattern (path.Match syntax, so a literal
+// bracket is written `\[`).
+func isBot(c contributor, extra []string) bool {
+	candidates := []string{strings.ToLower(c.name)}
+	for _, e := range c.emails {
+		candidates = append(candidates, strings.ToLower(e))
+	}
+	for _, patterns := range [][]string{knownBotPatterns, extra} {
+		for _, p := range patterns {
+			p = strings.ToLower(p)
+			for _, s := range candidates {
+				if ok, _ := path.Match(p, s); ok {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// splitBots separates automation accounts from the human cast.
+func splitBots(contributors []contributor, extra []string) (humans, bots []contributor) {
+	for _, c := range contributors {
+		if isBot(c, extra) {
+			bots = append(bots, c)
+		} else {
+			humans = append(humans, c)
+		}
+	}
+	return humans, bots
+}
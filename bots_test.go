@@ -0,0 +1,70 @@
+package main
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestIsBot(t *testing.T) {
+	tests := []struct {
+		c    contributor
+		want bool
+	}{
+		{contributor{name: "dependabot[bot]", emails: []string{"49699333+dependabot[bot]@users.noreply.github.com"}}, true},
+		{contributor{name: "Renovate Bot", emails: []string{"bot@renovateapp.com"}}, true},
+		{contributor{name: "github-actions", emails: []string{"41898282+github-actions[bot]@users.noreply.github.com"}}, true},
+		{contributor{name: "Robot Roberts", emails: []string{"robert@example.com"}}, false},
+		{contributor{name: "Abbot", emails: []string{"abbot@example.com"}}, false},
+	}
+	for _, tt := range tests {
+		if got := isBot(tt.c, nil); got != tt.want {
+			t.Errorf("isBot(%q) = %v, want %v", tt.c.name, got, tt.want)
+		}
+	}
+	if !isBot(contributor{name: "Deploy Helper", emails: []string{"deploy@ci.example.com"}}, []string{"*@ci.example.com"}) {
+		t.Error("user-supplied patterns should mark bots")
+	}
+}
+
+func TestParseArgs_BotPattern(t *testing.T) {
+	cfg, err := parseArgs([]string{"--bot-pattern", `*\[ci\]@*`})
+	if err != nil || len(cfg.botPatterns) != 1 {
+		t.Fatalf("valid pattern rejected: %v", err)
+	}
+	if _, err := parseArgs([]string{"--bot-pattern", "[abc"}); err == nil || !strings.Contains(err.Error(), "invalid --bot-pattern") {
+		t.Errorf("expected a malformed glob to be rejected, got %v", err)
+	}
+}
+
+func TestGetRepoInfo_BotModes(t *testing.T) {
+	repoDir := setupTestRepo(t)
+	for i := 0; i < 3; i++ {
+		commitAs(t, repoDir, "dependabot[bot]", "49699333+dependabot[bot]@users.noreply.github.com", "chore: bump deps")
+	}
+
+	info, err := getRepoInfo(context.Background(), repoDir, repoOptions{})
+	if err != nil {
+		t.Fatalf("getRepoInfo returned error: %v", err)
+	}
+	if len(info.contributors) != 1 || info.contributors[0].name != "Test User" {
+		t.Fatalf("bots should not lead the cast by default, got %+v", info.contributors)
+	}
+	if len(info.bots) != 1 || info.bots[0].commits != 3 {
+		t.Fatalf("expected dependabot under special effects, got %+v", info.bots)
+	}
+	text := strings.Join(buildCredits(info, blockFont, 80), "\n")
+	if !strings.Contains(text, "S P E C I A L   E F F E C T S   B Y") {
+		t.Error("credits should contain a SPECIAL EFFECTS BY section")
+	}
+
+	info, _ = getRepoInfo(context.Background(), repoDir, repoOptions{bots: "hide"})
+	if len(info.contributors) != 1 || len(info.bots) != 0 {
+		t.Fatalf("hide should drop bots entirely, got %+v / %+v", info.contributors, info.bots)
+	}
+
+	info, _ = getRepoInfo(context.Background(), repoDir, repoOptions{bots: "include"})
+	if len(info.contributors) != 2 || info.contributors[0].name != "dependabot[bot]" {
+		t.Fatalf("include should rank bots like humans, got %+v", info.contributors)
+	}
+}
@@ -0,0 +1,158 @@
+package main
+
+import (
+	"strings"
+)
+
+// highlight is a notable commit parsed from a Conventional Commits subject
+// such as "feat(api)!: drop v1 endpoints".
+type highlight struct {
+	kind        string // commit type, lowercased: "feat", "fix", "perf", ...
+	scope       string // optional scope, e.g. "api"
+	description string
+	breaking    bool // "!" after the type/scope or a BREAKING CHANGE footer
+}
+
+// defaultHighlightTypes are the commit types shown as notable scenes when
+// --types is not given. Breaking changes are shown whatever their type.
+var defaultHighlightTypes = []string{"feat", "fix", "perf"}
+
+// highlightCategories names each type's section, in display order.
+var highlightCategories = []struct {
+	kind  string
+	title string
+}{
+	{"feat", "Features"},
+	{"fix", "Fixes"},
+	{"perf", "Performance"},
+	{"refactor", "Refactoring"},
+	{"revert", "Reverts"},
+	{"docs", "Documentation"},
+	{"test", "Tests"},
+	{"build", "Build"},
+	{"ci", "Continuous Integration"},
+	{"style", "Style"},
+	{"chore", "Chores"},
+}
+
+// highlightGroup is one category of notable scenes.
+type highlightGroup struct {
+	title string
+	items []highlight
+}
+
+// parseConventionalCommit parses a commit subject and body. It reports
+// false for messages that do not follow the Conventional Commits format.
+func parseConventionalCommit(subject, body string) (highlight, bool) {
+	subject = strings.TrimSpace(subject)
+	colon := strings.Index(subject, ":")
+	if colon <= 0 {
+		return highlight{}, false
+	}
+	head, desc := subject[:colon], strings.TrimSpace(subject[colon+1:])
+	if desc == "" {
+		return highlight{}, false
+	}
+
+	var h highlight
+	if strings.HasSuffix(head, "!") {
+		h.breaking = true
+		head = head[:len(head)-1]
+	}
+	if open := strings.IndexByte(head, '('); open >= 0 {
+		if !strings.HasSuffix(head, ")") {
+			return highlight{}, false
+		}
+		h.scope = strings.TrimSpace(head[open+1 : len(head)-1])
+		head = head[:open]
+	}
+	if head == "" {
+		return highlight{}, false
+	}
+	for _, r := range head {
+		if !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r == '-') {
+			return highlight{}, false
+		}
+	}
+	h.kind = strings.ToLower(head)
+	h.description = desc
+
+	for _, line := range strings.Split(body, "\n") {
+		if strings.HasPrefix(line, "BREAKING CHANGE:") || strings.HasPrefix(line, "BREAKING-CHANGE:") {
+			h.breaking = true
+			break
+		}
+	}
+	return h, true
+}
+
+// groupHighlights sorts highlights into category sections. Breaking changes
+// come first; unknown types follow the known ones in order of appearance.
+func groupHighlights(highlights []highlight) []highlightGroup {
+	var groups []highlightGroup
+	index := make(map[string]int)
+	add := func(key, title string, h highlight) {
+		i, ok := index[key]
+		if !ok {
+			i = len(groups)
+			index[key] = i
+			groups = append(groups, highlightGroup{title: title})
+		}
+		groups[i].items = append(groups[i].items, h)
+	}
+
+	for _, h := range highlights {
+		if h.breaking {
+			add("!", "Breaking Changes", h)
+		}
+	}
+	for _, cat := range highlightCategories {
+		for _, h := range highlights {
+			if !h.breaking && h.kind == cat.kind {
+				add(cat.kind, cat.title, h)
+			}
+		}
+	}
+	for _, h := range highlights {
+		if !h.breaking && !isKnownHighlightKind(h.kind) {
+			add(h.kind, strings.ToUpper(h.kind[:1])+h.kind[1:], h)
+		}
+	}
+	return groups
+}
+
+func isKnownHighlightKind(kind string) bool {
+	for _, cat := range highlightCategories {
+		if cat.kind == kind {
+			return true
+		}
+	}
+	return false
+}
+
+// wantsHighlight reports whether h should be a notable scene.
+func wantsHighlight(h highlight, types []string) bool {
+	if h.breaking {
+		return true
+	}
+	if len(types) == 0 {
+		types = defaultHighlightTypes
+	}
+	for _, t := range types {
+		if t == h.kind {
+			return true
+		}
+	}
+	return false
+}
+
+// parseHighlightTypes parses a --types value like "feat,fix,perf".
+func parseHighlightTypes(s string) []string {
+	var types []string
+	for _, t := range strings.Split(s, ",") {
+		if t = strings.ToLower(strings.TrimSpace(t)); t != "" {
+			types = append(types, t)
+		}
+	}
+	return types
+}
@@ -0,0 +1,82 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseConventionalCommit(t *testing.T) {
+	tests := []struct {
+		subject, body string
+		want          highlight
+		ok            bool
+	}{
+		{"feat: add login", "", highlight{kind: "feat", description: "add login"}, true},
+		{"feat(api): add tokens", "", highlight{kind: "feat", scope: "api", description: "add tokens"}, true},
+		{"fix!: drop legacy flag", "", highlight{kind: "fix", description: "drop legacy flag", breaking: true}, true},
+		{"refactor(core)!: new engine", "", highlight{kind: "refactor", scope: "core", description: "new engine", breaking: true}, true},
+		{"Perf: faster scans", "", highlight{kind: "perf", description: "faster scans"}, true},
+		{"feat: config v2", "Rewrites the loader.\n\nBREAKING CHANGE: old files are ignored", highlight{kind: "feat", description: "config v2", breaking: true}, true},
+		{"Merge branch 'main'", "", highlight{}, false},
+		{"feat:", "", highlight{}, false},
+		{"fix typo: readme", "", highlight{}, false},
+		{"feat(api: broken", "", highlight{}, false},
+	}
+	for _, tt := range tests {
+		got, ok := parseConventionalCommit(tt.subject, tt.body)
+		if ok != tt.ok || got != tt.want {
+			t.Errorf("parseConventionalCommit(%q) = %+v, %v; want %+v, %v", tt.subject, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestGroupHighlights(t *testing.T) {
+	groups := groupHighlights([]highlight{
+		{kind: "fix", description: "a"},
+		{kind: "feat", description: "b"},
+		{kind: "feat", description: "c", breaking: true},
+		{kind: "perf", description: "d"},
+		{kind: "deps", description: "e"},
+	})
+	var titles []string
+	for _, g := range groups {
+		titles = append(titles, g.title)
+	}
+	got := strings.Join(titles, ",")
+	if got != "Breaking Changes,Features,Fixes,Performance,Deps" {
+		t.Fatalf("unexpected group order: %s", got)
+	}
+}
+
+func TestWantsHighlight(t *testing.T) {
+	if !wantsHighlight(highlight{kind: "perf"}, nil) {
+		t.Error("perf should be included by default")
+	}
+	if wantsHighlight(highlight{kind: "docs"}, nil) {
+		t.Error("docs should be excluded by default")
+	}
+	if !wantsHighlight(highlight{kind: "docs"}, parseHighlightTypes("Docs, feat")) {
+		t.Error("docs should be included when configured")
+	}
+	if !wantsHighlight(highlight{kind: "chore", breaking: true}, []string{"feat"}) {
+		t.Error("breaking changes should always be included")
+	}
+}
+
+func TestBuildCredits_GroupedScenes(t *testing.T) {
+	info := repoInfo{
+		name:         "test",
+		totalCommits: 2,
+		contributors: []contributor{{name: "Alice", commits: 2}},
+		highlights: []highlight{
+			{kind: "feat", scope: "api", description: "add tokens"},
+			{kind: "fix", description: "stop crash"},
+		},
+	}
+	text := strings.Join(buildCredits(info, blockFont, 80), "\n")
+	for _, want := range []string{"— FEATURES —", "· add tokens ·", "api", "— FIXES —", "· stop crash ·"} {
+		if !strings.Contains(text, want) {
+			t.Errorf("credits should contain %q", want)
+		}
+	}
+}
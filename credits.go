@@ -5,94 +5,240 @@ import (
 	"strings"
 )
 
-func buildCredits(info repoInfo, width int) []string {
-	var lines []string
+// buildCredits returns the plain text of the default theme's scroll.
+func buildCredits(info repoInfo, titleFont *font, width int) []string {
+	lines := layoutCredits(buildCreditsDoc(info), titleFont, width)
+	text := make([]string, len(lines))
+	for i, l := range lines {
+		text[i] = l.text
+	}
+	return text
+}
 
-	center := func(s string) string {
-		return centerText(s, width)
+// layoutCredits lays the document out as the default theme's scroll:
+// centered lines that keep their kind for styling. The title and the
+// closing "THE END" are fitted to width in titleFont, and other lines are
+// wrapped.
+func layoutCredits(doc creditsDoc, titleFont *font, width int) []creditLine {
+	var lines []creditLine
+
+	emit := func(kind creditKind, s string) {
+		for _, part := range wrapText(s, width-2*layoutMargin) {
+			lines = append(lines, creditLine{kind: kind, text: centerText(part, width)})
+		}
 	}
 
 	blank := func(n int) {
 		for i := 0; i < n; i++ {
-			lines = append(lines, "")
+			lines = append(lines, creditLine{kind: kindBlank})
 		}
 	}
 
 	blank(20)
 
-	titleRows := bigText(info.name)
-	for _, row := range titleRows {
-		lines = append(lines, center(row))
+	for _, s := range doc.sections {
+		switch s.kind {
+		case sectionTitle:
+			for _, l := range s.blocks[0].lines {
+				switch l.kind {
+				case kindTitle:
+					for _, row := range fitTitle(l.text, titleFont, width) {
+						emit(kindTitle, row)
+					}
+					blank(2)
+				case kindQuote:
+					if lines[len(lines)-1].kind == kindTagline {
+						blank(1)
+					}
+					emit(l.kind, l.text)
+				default:
+					emit(l.kind, l.text)
+				}
+			}
+			blank(6)
+
+		case sectionLead, sectionCast:
+			emit(kindHeading, spacedCaps(s.heading))
+			blank(2)
+			for _, b := range s.blocks {
+				for _, l := range b.lines {
+					switch {
+					case l.kind == kindName:
+						emit(l.kind, strings.ToUpper(l.text))
+						if s.kind == sectionLead {
+							blank(1)
+						}
+					case l.kind == kindSummary && s.kind == sectionLead:
+						emit(l.kind, "— "+l.text+" —")
+					case l.kind == kindImpact:
+						emit(kindDetail, l.text)
+					default:
+						emit(l.kind, l.text)
+					}
+				}
+				if s.kind == sectionCast {
+					blank(1)
+				}
+			}
+			if s.kind == sectionLead {
+				blank(6)
+			}
+
+		case sectionDepartment, sectionEffects:
+			blank(5)
+			emit(kindHeading, spacedCaps(s.heading))
+			blank(2)
+			for _, b := range s.blocks {
+				for _, l := range b.lines {
+					if s.kind == sectionEffects {
+						emit(kindName, roleCreditLine(l))
+						continue
+					}
+					emit(kindName, strings.ToUpper(l.text))
+					emit(kindSummary, fmt.Sprintf("%d commits", l.count))
+				}
+				blank(1)
+			}
+
+		case sectionScenes:
+			blank(5)
+			emit(kindHeading, spacedCaps(s.heading))
+			blank(2)
+			for _, b := range s.blocks {
+				for _, l := range b.lines {
+					switch l.kind {
+					case kindSubheading:
+						emit(l.kind, "— "+strings.ToUpper(l.text)+" —")
+						blank(1)
+					case kindScene:
+						emit(l.kind, "· "+l.text+" ·")
+						if l.note != "" {
+							emit(kindDetail, l.note)
+						}
+						blank(1)
+					}
+				}
+				blank(1)
+			}
+
+		case sectionStats:
+			blank(5)
+			emit(kindDivider, "━━━━━━━━━━━━━━━━━━━━")
+			blank(2)
+			for i, b := range s.blocks {
+				if i > 0 && b.lines[0].kind == kindStar {
+					blank(1)
+				} else if i > 0 {
+					blank(2)
+				}
+				for j, l := range b.lines {
+					switch l.kind {
+					case kindStat:
+						if j > 0 {
+							blank(1)
+						}
+						emit(l.kind, fmt.Sprintf("%d  %s", l.count, spacedCaps(l.text)))
+					case kindStar:
+						emit(l.kind, fmt.Sprintf("★  %d  %s  ★", l.count, spacedCaps(l.text)))
+					case kindLanguages:
+						emit(kindFact, "Written in "+l.text)
+					case kindLicense:
+						emit(kindFact, "Licensed under "+l.text)
+					default:
+						emit(l.kind, l.text)
+					}
+				}
+			}
+			blank(2)
+			emit(kindDivider, "━━━━━━━━━━━━━━━━━━━━")
+			blank(6)
+
+		case sectionEnd:
+			for _, row := range fitTitle(s.blocks[0].lines[0].text, titleFont, width) {
+				emit(kindEnd, row)
+			}
+		}
 	}
 
-	blank(2)
+	blank(20)
 
-	if info.description != "" {
-		lines = append(lines, center("\""+info.description+"\""))
-	}
+	return lines
+}
 
-	blank(6)
+// maxDebutLength caps the quoted first-commit subject in careerLine.
+const maxDebutLength = 40
 
-	if len(info.contributors) > 0 {
-		lines = append(lines, center("A   P R O J E C T   B Y"))
-		blank(2)
-		lines = append(lines, center(strings.ToUpper(info.contributors[0].name)))
-		blank(1)
-		lines = append(lines, center(fmt.Sprintf("— %d commits —", info.contributors[0].commits)))
+// careerLine describes when a contributor started and with which commit,
+// e.g. "since 2021 · debut: 'fix typo in README'", or "" when the history
+// carried no dates. A Conventional Commits prefix is dropped from the debut.
+func careerLine(c contributor) string {
+	if c.first.IsZero() {
+		return ""
 	}
-
-	blank(6)
-
-	if len(info.contributors) > 1 {
-		lines = append(lines, center("S T A R R I N G"))
-		blank(2)
-		for _, c := range info.contributors[1:] {
-			lines = append(lines, center(strings.ToUpper(c.name)))
-			lines = append(lines, center(fmt.Sprintf("%d commits", c.commits)))
-			blank(1)
-		}
+	line := fmt.Sprintf("since %d", c.first.Year())
+	debut := c.debut
+	if h, ok := parseConventionalCommit(debut, ""); ok {
+		debut = h.description
+	}
+	if debut != "" {
+		line += " · debut: '" + truncateDescription(debut, maxDebutLength) + "'"
 	}
+	return line
+}
 
-	blank(5)
+// commitSummary describes a contributor's commits, calling out co-authored
+// work separately from commits they authored themselves.
+func commitSummary(c contributor) string {
+	switch {
+	case c.coAuthored == 0:
+		return fmt.Sprintf("%d commits", c.commits)
+	case c.authored == 0:
+		return fmt.Sprintf("%d co-authored commits", c.coAuthored)
+	default:
+		return fmt.Sprintf("%d commits · %d co-authored", c.authored, c.coAuthored)
+	}
+}
 
-	if len(info.highlights) > 0 {
-		lines = append(lines, center("N O T A B L E   S C E N E S"))
-		blank(2)
-		for _, h := range info.highlights {
-			lines = append(lines, center("· "+h+" ·"))
-			blank(1)
-		}
+// roleCreditLine is a compact "NAME  ×3" entry for department cards.
+func roleCreditLine(l creditLine) string {
+	if l.count > 1 {
+		return fmt.Sprintf("%s  ×%d", strings.ToUpper(l.text), l.count)
 	}
+	return strings.ToUpper(l.text)
+}
 
-	blank(5)
+// spacedCaps renders a heading in the credits' letter-spaced capitals,
+// e.g. "REVIEWED BY" becomes "R E V I E W E D   B Y". Spaces go between
+// grapheme clusters, so conjuncts, accents and emoji stay whole.
+func spacedCaps(s string) string {
+	return strings.Join(graphemeClusters(strings.ToUpper(s)), " ")
+}
 
-	lines = append(lines, center("━━━━━━━━━━━━━━━━━━━━"))
-	blank(2)
-	lines = append(lines, center(fmt.Sprintf("%d  C O M M I T S", info.totalCommits)))
-	blank(1)
-	lines = append(lines, center(fmt.Sprintf("%d  C O N T R I B U T O R S", len(info.contributors))))
-	if info.stars > 0 {
-		blank(1)
-		lines = append(lines, center(fmt.Sprintf("★  %d  S T A R G A Z E R S  ★", info.stars)))
+// titleTaglines returns the lines shown under the big title: which release
+// or time window is being credited, and the release's tag message.
+func titleTaglines(info repoInfo) []string {
+	var label []string
+	if info.release != "" {
+		label = append(label, info.release)
 	}
-	blank(2)
-	if info.language != "" {
-		lines = append(lines, center("Written in "+info.language))
+	if info.window != "" {
+		label = append(label, info.window)
 	}
-	if info.license != "" {
-		lines = append(lines, center("Licensed under "+info.license))
+	if len(label) == 0 {
+		return nil
 	}
-	blank(2)
-	lines = append(lines, center("━━━━━━━━━━━━━━━━━━━━"))
-
-	blank(6)
-
-	endRows := bigText("THE END")
-	for _, row := range endRows {
-		lines = append(lines, center(row))
+	lines := []string{"— " + strings.Join(label, " · ") + " —"}
+	if info.releaseNote != "" {
+		lines = append(lines, info.releaseNote)
 	}
-
-	blank(20)
-
 	return lines
 }
+
+// sceneLine is the one-line form of a scene used on cards, where the scope
+// follows the description instead of sitting beneath it.
+func sceneLine(l creditLine) string {
+	if l.note != "" {
+		return l.text + "  (" + l.note + ")"
+	}
+	return l.text
+}
@@ -3,6 +3,7 @@ package main
 import (
 	"strings"
 	"testing"
+	"time"
 )
 
 func TestBuildCredits_HasTitle(t *testing.T) {
@@ -14,7 +15,7 @@ func TestBuildCredits_HasTitle(t *testing.T) {
 			{name: "Bob", commits: 12},
 		},
 	}
-	lines := buildCredits(info, 80)
+	lines := buildCredits(info, blockFont, 80)
 
 	if len(lines) == 0 {
 		t.Fatal("buildCredits returned empty")
@@ -41,7 +42,7 @@ func TestBuildCredits_HasProjectBy(t *testing.T) {
 			{name: "Alice", commits: 10},
 		},
 	}
-	lines := buildCredits(info, 80)
+	lines := buildCredits(info, blockFont, 80)
 
 	found := false
 	for _, l := range lines {
@@ -64,7 +65,7 @@ func TestBuildCredits_HasStarring(t *testing.T) {
 			{name: "Bob", commits: 5},
 		},
 	}
-	lines := buildCredits(info, 80)
+	lines := buildCredits(info, blockFont, 80)
 
 	found := false
 	for _, l := range lines {
@@ -86,7 +87,7 @@ func TestBuildCredits_HasTheEnd(t *testing.T) {
 			{name: "Alice", commits: 1},
 		},
 	}
-	lines := buildCredits(info, 80)
+	lines := buildCredits(info, blockFont, 80)
 
 	found := false
 	for _, l := range lines {
@@ -115,7 +116,7 @@ func TestBuildCredits_CommitCount(t *testing.T) {
 			{name: "Alice", commits: 99},
 		},
 	}
-	lines := buildCredits(info, 80)
+	lines := buildCredits(info, blockFont, 80)
 
 	found := false
 	for _, l := range lines {
@@ -129,14 +130,14 @@ func TestBuildCredits_CommitCount(t *testing.T) {
 	}
 }
 
-func TestBigText(t *testing.T) {
-	rows := bigText("AB")
+func TestBlockFont_Render(t *testing.T) {
+	rows := blockFont.render("AB")
 	if len(rows) != 5 {
-		t.Fatalf("bigText should return 5 rows, got %d", len(rows))
+		t.Fatalf("block font should draw 5 rows, got %d", len(rows))
 	}
 	for _, r := range rows {
 		if len(r) == 0 {
-			t.Error("bigText row should not be empty")
+			t.Error("block font row should not be empty")
 		}
 	}
 }
@@ -150,3 +151,43 @@ func TestCenterText(t *testing.T) {
 		t.Errorf("expected 'hi' centered, got %q", result)
 	}
 }
+
+func TestBuildCredits_CoAuthorInStarring(t *testing.T) {
+	info := repoInfo{
+		name:         "test",
+		totalCommits: 5,
+		contributors: []contributor{
+			{name: "Alice", commits: 5, authored: 5},
+			{name: "Pat", commits: 2, coAuthored: 2},
+		},
+	}
+	text := strings.Join(buildCredits(info, blockFont, 80), "\n")
+
+	if !strings.Contains(text, "PAT") || !strings.Contains(text, "2 co-authored commits") {
+		t.Error("credits should list co-authors with their co-authored commit count")
+	}
+}
+
+func TestBuildCredits_Career(t *testing.T) {
+	info := repoInfo{
+		name:         "test",
+		totalCommits: 12,
+		contributors: []contributor{
+			{name: "Alice", commits: 10},
+			{name: "Bob", commits: 2, first: time.Date(2021, 3, 1, 0, 0, 0, 0, time.UTC), debut: "fix typo in README"},
+		},
+	}
+	want := "since 2021 · debut: 'fix typo in README'"
+	for name, lines := range map[string][]string{
+		"default":   buildCredits(info, blockFont, 80),
+		"matrix":    cardLines(buildMatrixCards(info, blockFont, 80, 24)),
+		"spiderman": cardLines(buildSpidermanCards(info, blockFont, 80, 24)),
+	} {
+		if !strings.Contains(strings.Join(lines, "\n"), want) {
+			t.Errorf("%s theme is missing %q", name, want)
+		}
+	}
+	if got := careerLine(info.contributors[0]); got != "" {
+		t.Errorf("undated contributor: got %q", got)
+	}
+}
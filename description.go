@@ -0,0 +1,257 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/mattn/go-runewidth"
+)
+
+// descriptionSources lists the places a tagline can come from, in the
+// default order they are tried:
+//
+//	git             .git/description, unless it is git's placeholder
+//	forge           the hosting service's repository description
+//	readme          the first prose paragraph of the README
+//	package.json    "description"
+//	Cargo.toml      [package] description
+//	pyproject.toml  [project] or [tool.poetry] description
+//	go.mod          the comment above the module line
+var descriptionSources = []string{"git", "forge", "readme", "package.json", "Cargo.toml", "pyproject.toml", "go.mod"}
+
+// maxDescriptionLength caps the tagline so it fits one line of an
+// 80-column card with its quotes.
+const maxDescriptionLength = 72
+
+// parseDescriptionOrder parses a --description-from list such as
+// "readme,package.json,forge". Sources left out are not consulted.
+func parseDescriptionOrder(s string) ([]string, error) {
+	var order []string
+	for _, name := range strings.Split(s, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		found := false
+		for _, known := range descriptionSources {
+			if strings.EqualFold(name, known) {
+				order = append(order, known)
+				found = true
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("invalid description source %q, expected one of: %s", name, strings.Join(descriptionSources, ", "))
+		}
+	}
+	if len(order) == 0 {
+		return nil, fmt.Errorf("empty --description-from list")
+	}
+	return order, nil
+}
+
+// resolveDescription walks the sources in order and returns the first
+// description found, shortened to fit a card. The git and forge values are
+// collected elsewhere; project files are read from rev's tree.
+func resolveDescription(ctx context.Context, dir, rev string, order []string, gitDescription, forgeDescription string) string {
+	if len(order) == 0 {
+		order = descriptionSources
+	}
+	for _, name := range order {
+		var d string
+		switch name {
+		case "git":
+			d = gitDescription
+		case "forge":
+			d = forgeDescription
+		case "readme":
+			for _, f := range []string{"README.md", "README.markdown", "readme.md", "README", "README.txt"} {
+				if text, ok := readTreeFile(ctx, dir, rev, f); ok {
+					d = readmeParagraph(text)
+					break
+				}
+			}
+		case "package.json":
+			if text, ok := readTreeFile(ctx, dir, rev, name); ok {
+				var pkg struct {
+					Description string `json:"description"`
+				}
+				if json.Unmarshal([]byte(text), &pkg) == nil {
+					d = pkg.Description
+				}
+			}
+		case "Cargo.toml":
+			if text, ok := readTreeFile(ctx, dir, rev, name); ok {
+				d = tomlString(text, "package", "description")
+			}
+		case "pyproject.toml":
+			if text, ok := readTreeFile(ctx, dir, rev, name); ok {
+				if d = tomlString(text, "project", "description"); d == "" {
+					d = tomlString(text, "tool.poetry", "description")
+				}
+			}
+		case "go.mod":
+			if text, ok := readTreeFile(ctx, dir, rev, name); ok {
+				d = goModComment(text)
+			}
+		}
+		if d = strings.Join(strings.Fields(d), " "); d != "" {
+			return truncateDescription(d, maxDescriptionLength)
+		}
+	}
+	return ""
+}
+
+// readTreeFile reads a file at the root of rev's tree.
+func readTreeFile(ctx context.Context, dir, rev, name string) (string, bool) {
+	out, err := runCommand(ctx, dir, "git", "cat-file", "blob", rev+":"+name)
+	if err != nil {
+		return "", false
+	}
+	return string(out), true
+}
+
+// truncateDescription shortens s to at most max terminal cells, cutting at
+// a word boundary and marking the cut with an ellipsis.
+func truncateDescription(s string, max int) string {
+	if displayWidth(s) <= max {
+		return s
+	}
+	cut := runewidth.Truncate(s, max-1, "")
+	if i := strings.LastIndexByte(cut, ' '); i > len(cut)/2 {
+		cut = cut[:i]
+	}
+	return strings.TrimRight(cut, " ,.;:-") + "…"
+}
+
+var (
+	mdImage      = regexp.MustCompile(`!\[[^\]]*\]\([^)]*\)`)
+	mdLink       = regexp.MustCompile(`\[([^\]]*)\]\([^)]*\)`)
+	mdRefLink    = regexp.MustCompile(`\[([^\]]*)\]\[[^\]]*\]`)
+	mdHTMLTag    = regexp.MustCompile(`<[^>]+>`)
+	mdEmphasis   = regexp.MustCompile(`(\*\*|__|\*|~~|` + "`" + `)([^*~` + "`" + `]+)(\*\*|__|\*|~~|` + "`" + `)`)
+	mdListMarker = regexp.MustCompile(`^([-*+]|\d+[.)])\s`)
+)
+
+// readmeParagraph returns the first paragraph of prose in a README with
+// markdown stripped, skipping headings, badges, images, code blocks, lists,
+// tables and HTML.
+func readmeParagraph(text string) string {
+	var para []string
+	inFence := false
+	scanner := bufio.NewScanner(strings.NewReader(text))
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if strings.HasPrefix(line, "```") || strings.HasPrefix(line, "~~~") {
+			inFence = !inFence
+			continue
+		}
+		if inFence {
+			continue
+		}
+		if line == "" {
+			if len(para) > 0 {
+				break
+			}
+			continue
+		}
+		if isSetextUnderline(line) {
+			para = nil // the previous line was a heading
+			continue
+		}
+		if strings.HasPrefix(line, "#") || strings.HasPrefix(line, "|") || mdListMarker.MatchString(line) {
+			if len(para) > 0 {
+				break
+			}
+			continue
+		}
+		line = strings.TrimSpace(strings.TrimLeft(line, ">"))
+		line = mdImage.ReplaceAllString(line, "")
+		line = mdLink.ReplaceAllString(line, "$1")
+		line = mdRefLink.ReplaceAllString(line, "$1")
+		line = mdHTMLTag.ReplaceAllString(line, "")
+		for prev := ""; prev != line; {
+			prev = line
+			line = mdEmphasis.ReplaceAllString(line, "$2")
+		}
+		if line = strings.TrimSpace(line); line != "" {
+			para = append(para, line)
+		}
+	}
+	return strings.Join(para, " ")
+}
+
+func isSetextUnderline(line string) bool {
+	return len(line) >= 2 && (strings.Trim(line, "=") == "" || strings.Trim(line, "-") == "")
+}
+
+// tomlString finds key in [table] and returns its string value. It handles
+// the basic, literal and multi-line strings project manifests use, not
+// TOML in general.
+func tomlString(text, table, key string) string {
+	lines := strings.Split(text, "\n")
+	current := ""
+	for i := 0; i < len(lines); i++ {
+		line := strings.TrimSpace(lines[i])
+		if strings.HasPrefix(line, "[") {
+			header, _, _ := strings.Cut(strings.TrimLeft(line, "["), "]")
+			current = strings.TrimSpace(header)
+			continue
+		}
+		if current != table {
+			continue
+		}
+		k, v, ok := strings.Cut(line, "=")
+		if !ok || strings.Trim(strings.TrimSpace(k), `"'`) != key {
+			continue
+		}
+		v = strings.TrimSpace(v)
+		for _, quote := range []string{`"""`, `'''`} {
+			if strings.HasPrefix(v, quote) {
+				body := v[len(quote):]
+				for !strings.Contains(body, quote) && i+1 < len(lines) {
+					i++
+					body += "\n" + lines[i]
+				}
+				body, _, _ = strings.Cut(body, quote)
+				return strings.TrimSpace(body)
+			}
+		}
+		switch {
+		case strings.HasPrefix(v, `"`):
+			end := strings.LastIndexByte(v, '"')
+			if s, err := strconv.Unquote(v[:end+1]); err == nil {
+				return s
+			}
+			return strings.Trim(v[:end+1], `"`)
+		case strings.HasPrefix(v, "'"):
+			s, _, _ := strings.Cut(v[1:], "'")
+			return s
+		}
+		return ""
+	}
+	return ""
+}
+
+// goModComment returns the // comment block directly above the module
+// directive, the only place go.mod has for a description.
+func goModComment(text string) string {
+	var comment []string
+	for _, line := range strings.Split(text, "\n") {
+		line = strings.TrimSpace(line)
+		switch {
+		case strings.HasPrefix(line, "//"):
+			comment = append(comment, strings.TrimSpace(strings.TrimPrefix(line, "//")))
+		case strings.HasPrefix(line, "module"):
+			return strings.Join(comment, " ")
+		default:
+			comment = nil
+		}
+	}
+	return ""
+}
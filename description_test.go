@@ -0,0 +1,136 @@
+package main
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestReadmeParagraph(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want string
+	}{
+		{"project README", "# gitcredits\n\n**`Git log` doesn't do them justice. Turn your contributors into movie stars.**\n\n<p align=\"center\">\n", "Git log doesn't do them justice. Turn your contributors into movie stars."},
+		{"badges and links", "# Rocket\n\n[![CI](https://x/badge.svg)](https://x) ![logo](logo.png)\n\nA [fast](https://rocket.dev) rocket\nfor *everyone*.\n\nMore text.", "A fast rocket for everyone."},
+		{"setext heading and list", "Rocket\n======\n\n- item one\n- item two\n\nThe real intro.", "The real intro."},
+		{"code fence first", "```sh\nmake install\n```\n\n> Quoted intro with snake_case_names.", "Quoted intro with snake_case_names."},
+		{"only headings", "# test\n", ""},
+	}
+	for _, tt := range tests {
+		if got := readmeParagraph(tt.text); got != tt.want {
+			t.Errorf("%s: got %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestTomlString(t *testing.T) {
+	cargo := "[package]\nname = \"rocket\"\ndescription = \"A \\\"fast\\\" rocket\" # why not\n\n[dependencies]\ndescription = \"wrong\"\n"
+	if got := tomlString(cargo, "package", "description"); got != `A "fast" rocket` {
+		t.Errorf("Cargo.toml: got %q", got)
+	}
+	poetry := "[tool.poetry]  # build\nname = 'rocket'\ndescription = \"\"\"\n  Multi-line\n  rocket\n\"\"\"\n"
+	if got := tomlString(poetry, "tool.poetry", "description"); got != "Multi-line\n  rocket" {
+		t.Errorf("pyproject.toml: got %q", got)
+	}
+	if got := tomlString("[project]\ndescription = 'literal \\n'\n", "project", "description"); got != `literal \n` {
+		t.Errorf("literal string: got %q", got)
+	}
+	if got := tomlString(cargo, "project", "description"); got != "" {
+		t.Errorf("missing table: got %q", got)
+	}
+}
+
+func TestGoModComment(t *testing.T) {
+	mod := "// Rocket launches things.\n// Quickly.\nmodule example.com/rocket\n\ngo 1.21\n"
+	if got := goModComment(mod); got != "Rocket launches things. Quickly." {
+		t.Errorf("got %q", got)
+	}
+	if got := goModComment("// unrelated\n\nmodule example.com/rocket\n"); got != "" {
+		t.Errorf("detached comment: got %q", got)
+	}
+}
+
+func TestTruncateDescription(t *testing.T) {
+	long := "A tool that turns the history of any git repository into movie-style rolling credits in your terminal"
+	got := truncateDescription(long, 40)
+	if got != "A tool that turns the history of any…" {
+		t.Errorf("got %q", got)
+	}
+	if got := truncateDescription("short", 40); got != "short" {
+		t.Errorf("got %q", got)
+	}
+	// wide characters count two cells each
+	if got := truncateDescription("ロケットを宇宙へ打ち上げるためのツール", 20); got != "ロケットを宇宙へ打…" || displayWidth(got) > 20 {
+		t.Errorf("wide: got %q", got)
+	}
+	if got := truncateDescription("👩‍💻 builds rockets for the whole team", 20); displayWidth(got) > 20 || !strings.HasPrefix(got, "👩‍💻 ") {
+		t.Errorf("emoji: got %q", got)
+	}
+}
+
+func TestParseDescriptionOrder(t *testing.T) {
+	order, err := parseDescriptionOrder("README, cargo.toml,forge")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(order) != 3 || order[0] != "readme" || order[1] != "Cargo.toml" || order[2] != "forge" {
+		t.Errorf("got %q", order)
+	}
+	if _, err := parseDescriptionOrder("readme,setup.py"); err == nil {
+		t.Error("expected error for unknown source")
+	}
+}
+
+func TestGetRepoInfo_DescriptionChain(t *testing.T) {
+	repoDir := setupTestRepo(t)
+	files := map[string]string{
+		"README.md":    "# Rocket\n\nRockets from the README.\n",
+		"package.json": `{"name": "rocket", "description": "Rockets from package.json"}`,
+	}
+	for name, text := range files {
+		if err := os.WriteFile(filepath.Join(repoDir, name), []byte(text), 0o644); err != nil {
+			t.Fatalf("write file: %v", err)
+		}
+	}
+	runInDir(t, repoDir, "git", "add", ".")
+	runInDir(t, repoDir, "git", "commit", "-m", "docs: describe rocket")
+
+	info, err := getRepoInfo(context.Background(), repoDir, repoOptions{offline: true})
+	if err != nil {
+		t.Fatalf("getRepoInfo returned error: %v", err)
+	}
+	if info.description != "Rockets from the README." {
+		t.Fatalf("expected README paragraph, got %q", info.description)
+	}
+
+	info, _ = getRepoInfo(context.Background(), repoDir, repoOptions{offline: true, descriptionOrder: []string{"package.json", "readme"}})
+	if info.description != "Rockets from package.json" {
+		t.Fatalf("expected package.json description, got %q", info.description)
+	}
+}
+
+func TestGetRepoInfo_DescriptionAtRange(t *testing.T) {
+	repoDir := setupTestRepo(t)
+	for _, step := range []struct{ text, tag string }{
+		{"# Rocket\n\nRockets, version one.\n", "v1.0.0"},
+		{"# Rocket\n\nRockets, version two.\n", "v2.0.0"},
+	} {
+		if err := os.WriteFile(filepath.Join(repoDir, "README.md"), []byte(step.text), 0o644); err != nil {
+			t.Fatalf("write file: %v", err)
+		}
+		runInDir(t, repoDir, "git", "commit", "-am", "docs: describe "+step.tag)
+		runInDir(t, repoDir, "git", "tag", step.tag)
+	}
+
+	info, err := getRepoInfo(context.Background(), repoDir, repoOptions{offline: true, rev: "HEAD~2..v1.0.0"})
+	if err != nil {
+		t.Fatalf("getRepoInfo returned error: %v", err)
+	}
+	if info.description != "Rockets, version one." {
+		t.Fatalf("expected the README as of v1.0.0, got %q", info.description)
+	}
+}
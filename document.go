@@ -0,0 +1,183 @@
+package main
+
+import "fmt"
+
+// creditKind is the semantic role of a line in the credits. Themes pick
+// their wording and colours from the kind rather than guessing from the
+// text, so a contributor named "THE ★ commits" is still styled as a name.
+type creditKind int
+
+const (
+	kindBlank      creditKind = iota // spacer
+	kindTitle                        // the project name, drawn in the big font
+	kindTagline                      // release or time window under the title
+	kindQuote                        // the project description
+	kindMeta                         // language and stars on a title card
+	kindHeading                      // section heading such as "STARRING"
+	kindSubheading                   // hero title or group of scenes
+	kindIntro                        // "INTRODUCING" before a newcomer
+	kindName                         // a credited person
+	kindSummary                      // a person's commit count
+	kindImpact                       // a person's lines added and removed
+	kindDetail                       // further lines under a name or scene
+	kindScene                        // a notable commit
+	kindStat                         // a headline number such as commits
+	kindStar                         // the stargazer count
+	kindFact                         // a timeline fact
+	kindLanguages                    // the language breakdown among the facts
+	kindLicense                      // the license, on a title card or among the facts
+	kindDivider                      // horizontal rule
+	kindEnd                          // closing line
+)
+
+// creditLine is one line of the credits. In a creditsDoc text is unstyled
+// and uncentered; themes decorate it when they lay the document out.
+type creditLine struct {
+	kind  creditKind
+	text  string
+	note  string // a scene's scope
+	count int    // a stat's value, or a department credit's commits
+}
+
+// sectionKind identifies a section of the credits.
+type sectionKind int
+
+const (
+	sectionTitle sectionKind = iota
+	sectionLead
+	sectionCast
+	sectionDepartment
+	sectionEffects
+	sectionScenes
+	sectionStats
+	sectionEnd
+)
+
+// creditBlock is a run of lines kept together, such as a person and their
+// subtitles or a group of scenes.
+type creditBlock struct {
+	lines  []creditLine
+	person *contributor // the credited contributor, for lead and cast blocks
+	rank   int          // the contributor's position in the billing
+}
+
+type creditSection struct {
+	kind    sectionKind
+	heading string // plain capitals; themes space or rename it
+	blocks  []creditBlock
+}
+
+// creditsDoc is the theme-independent content of the credits, built once
+// from repoInfo.
+type creditsDoc struct {
+	sections []creditSection
+}
+
+// buildCreditsDoc arranges repoInfo into sections in billing order.
+func buildCreditsDoc(info repoInfo) creditsDoc {
+	var doc creditsDoc
+	add := func(s creditSection) {
+		doc.sections = append(doc.sections, s)
+	}
+
+	title := creditBlock{lines: []creditLine{{kind: kindTitle, text: info.name}}}
+	for _, t := range titleTaglines(info) {
+		title.lines = append(title.lines, creditLine{kind: kindTagline, text: t})
+	}
+	if info.description != "" {
+		title.lines = append(title.lines, creditLine{kind: kindQuote, text: "\"" + info.description + "\""})
+	}
+	var meta creditBlock
+	if info.language != "" {
+		meta.lines = append(meta.lines, creditLine{kind: kindMeta, text: info.language})
+	}
+	if info.stars > 0 {
+		meta.lines = append(meta.lines, creditLine{kind: kindMeta, text: fmt.Sprintf("★ %d stars", info.stars), count: info.stars})
+	}
+	if info.license != "" {
+		meta.lines = append(meta.lines, creditLine{kind: kindLicense, text: info.license})
+	}
+	add(creditSection{kind: sectionTitle, blocks: []creditBlock{title, meta}})
+
+	for i := range info.contributors {
+		c := &info.contributors[i]
+		block := creditBlock{person: c, rank: i}
+		if c.newcomer {
+			block.lines = append(block.lines, creditLine{kind: kindIntro, text: "INTRODUCING"})
+		}
+		block.lines = append(block.lines,
+			creditLine{kind: kindName, text: c.name, count: c.commits},
+			creditLine{kind: kindSummary, text: commitSummary(*c)})
+		if impact := impactSummary(*c); impact != "" {
+			block.lines = append(block.lines, creditLine{kind: kindImpact, text: impact})
+		}
+		for _, d := range []string{repoBreakdown(*c), careerLine(*c)} {
+			if d != "" {
+				block.lines = append(block.lines, creditLine{kind: kindDetail, text: d})
+			}
+		}
+		if i == 0 {
+			add(creditSection{kind: sectionLead, heading: "A PROJECT BY", blocks: []creditBlock{block}})
+			continue
+		}
+		if i == 1 {
+			add(creditSection{kind: sectionCast, heading: "STARRING"})
+		}
+		cast := &doc.sections[len(doc.sections)-1]
+		cast.blocks = append(cast.blocks, block)
+	}
+
+	people := func(kind sectionKind, heading string, cs []contributor) {
+		s := creditSection{kind: kind, heading: heading}
+		for _, c := range cs {
+			s.blocks = append(s.blocks, creditBlock{lines: []creditLine{{kind: kindName, text: c.name, count: c.commits}}})
+		}
+		add(s)
+	}
+	for _, role := range info.roles {
+		people(sectionDepartment, role.title, role.people)
+	}
+	if len(info.bots) > 0 {
+		people(sectionEffects, "SPECIAL EFFECTS BY", info.bots)
+	}
+
+	if len(info.highlights) > 0 {
+		scenes := creditSection{kind: sectionScenes, heading: "NOTABLE SCENES"}
+		for _, g := range groupHighlights(info.highlights) {
+			block := creditBlock{lines: []creditLine{{kind: kindSubheading, text: g.title}}}
+			for _, h := range g.items {
+				block.lines = append(block.lines, creditLine{kind: kindScene, text: h.description, note: h.scope})
+			}
+			scenes.blocks = append(scenes.blocks, block)
+		}
+		add(scenes)
+	}
+
+	stats := creditSection{kind: sectionStats, blocks: []creditBlock{{lines: []creditLine{
+		{kind: kindStat, text: "COMMITS", count: info.totalCommits},
+		{kind: kindStat, text: "CONTRIBUTORS", count: len(info.contributors)},
+	}}}}
+	if info.stars > 0 {
+		stats.blocks = append(stats.blocks, creditBlock{lines: []creditLine{{kind: kindStar, text: "STARGAZERS", count: info.stars}}})
+	}
+	var story creditBlock
+	for _, l := range timelineLines(info.timeline) {
+		story.lines = append(story.lines, creditLine{kind: kindFact, text: l})
+	}
+	var facts creditBlock
+	if languages := languageSummary(info); languages != "" {
+		facts.lines = append(facts.lines, creditLine{kind: kindLanguages, text: languages})
+	}
+	if info.license != "" {
+		facts.lines = append(facts.lines, creditLine{kind: kindLicense, text: info.license})
+	}
+	for _, b := range []creditBlock{story, facts} {
+		if len(b.lines) > 0 {
+			stats.blocks = append(stats.blocks, b)
+		}
+	}
+	add(stats)
+
+	add(creditSection{kind: sectionEnd, blocks: []creditBlock{{lines: []creditLine{{kind: kindEnd, text: "THE END"}}}}})
+	return doc
+}
@@ -0,0 +1,73 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestBuildCreditsDoc_Sections(t *testing.T) {
+	info := repoInfo{
+		name:         "test",
+		totalCommits: 6,
+		contributors: []contributor{{name: "Alice", commits: 4}, {name: "Bob", commits: 2}},
+		roles:        []roleCredit{{title: "REVIEWED BY", people: []contributor{{name: "Rex", commits: 2}}}},
+		bots:         []contributor{{name: "dependabot[bot]", commits: 3}},
+		highlights:   []highlight{{kind: "feat", description: "add tokens"}},
+	}
+	var got []sectionKind
+	for _, s := range buildCreditsDoc(info).sections {
+		got = append(got, s.kind)
+	}
+	want := []sectionKind{sectionTitle, sectionLead, sectionCast, sectionDepartment, sectionEffects, sectionScenes, sectionStats, sectionEnd}
+	if len(got) != len(want) {
+		t.Fatalf("got sections %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("got sections %v, want %v", got, want)
+		}
+	}
+}
+
+func TestCredits_StyledByKind(t *testing.T) {
+	// a name that looks like a hero title, a stat and a star line
+	name := "THE ★ 99 commits"
+	info := repoInfo{
+		name:         "test",
+		totalCommits: 3,
+		contributors: []contributor{{name: "Alice", commits: 2}, {name: name, commits: 1}},
+	}
+
+	found := false
+	for _, l := range layoutCredits(buildCreditsDoc(info), blockFont, 80) {
+		if strings.TrimSpace(l.text) == strings.ToUpper(name) {
+			found = true
+			if l.kind != kindName {
+				t.Errorf("default theme: %q has kind %d, want kindName", l.text, l.kind)
+			}
+		}
+	}
+	if !found {
+		t.Fatal("default theme is missing the contributor")
+	}
+
+	for theme, cards := range map[string][]matrixCard{
+		"matrix":    buildMatrixCards(info, blockFont, 80, 24),
+		"spiderman": buildSpidermanCards(info, blockFont, 80, 24),
+	} {
+		found := false
+		for _, c := range cards {
+			for r, l := range c.lines {
+				if strings.Contains(l, "★") && strings.Contains(l, "9") {
+					found = true
+					if c.kindAt(r) != kindName {
+						t.Errorf("%s theme: %q has kind %d, want kindName", theme, strings.TrimSpace(l), c.kindAt(r))
+					}
+				}
+			}
+		}
+		if !found {
+			t.Errorf("%s theme is missing the contributor", theme)
+		}
+	}
+}
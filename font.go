@@ -0,0 +1,264 @@
+package main
+
+import (
+	"bufio"
+	"embed"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// fontFiles holds the title fonts that ship with gitcredits, in FIGlet's
+// .flf format: "block", the default, and "small", three rows high.
+//
+//go:embed fonts/*.flf
+var fontFiles embed.FS
+
+// blockFont is the default title font, and smallFont the compact one long
+// titles step down to.
+var (
+	blockFont = mustLoadEmbeddedFont("block")
+	smallFont = mustLoadEmbeddedFont("small")
+)
+
+// font is a FIGlet font. Glyphs are drawn side by side at full width;
+// kerning and smushing rules in the header are not applied.
+type font struct {
+	height int
+	glyphs map[rune][]string // every glyph has height rows of equal width
+}
+
+// deutschCodes are the characters an flf2 font lists after ASCII.
+var deutschCodes = []rune{'Ä', 'Ö', 'Ü', 'ä', 'ö', 'ü', 'ß'}
+
+// latinFolds spells letters that don't decompose into a base letter and a
+// combining accent.
+var latinFolds = map[rune]string{
+	'ß': "ss", 'æ': "ae", 'Æ': "AE", 'œ': "oe", 'Œ': "OE", 'ø': "o", 'Ø': "O",
+	'ł': "l", 'Ł': "L", 'đ': "d", 'Đ': "D", 'ð': "d", 'Ð': "D", 'þ': "th",
+	'Þ': "TH", 'ı': "i", 'ŀ': "l", 'Ŀ': "L", 'ħ': "h", 'Ħ': "H",
+}
+
+// embeddedFontNames lists the fonts --font accepts by name.
+func embeddedFontNames() []string {
+	files, _ := fontFiles.ReadDir("fonts")
+	var names []string
+	for _, f := range files {
+		names = append(names, strings.TrimSuffix(f.Name(), ".flf"))
+	}
+	sort.Strings(names)
+	return names
+}
+
+// loadFont returns the embedded font called name, or else reads name as a
+// path to an .flf file.
+func loadFont(name string) (*font, error) {
+	if f, err := fontFiles.Open(path.Join("fonts", strings.TrimSuffix(name, ".flf")+".flf")); err == nil {
+		defer f.Close()
+		return parseFont(f)
+	}
+	f, err := os.Open(name)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf("unknown font %q, expected one of: %s, or a path to an .flf file", name, strings.Join(embeddedFontNames(), ", "))
+		}
+		return nil, fmt.Errorf("open font: %w", err)
+	}
+	defer f.Close()
+	fnt, err := parseFont(f)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", name, err)
+	}
+	return fnt, nil
+}
+
+func mustLoadEmbeddedFont(name string) *font {
+	f, err := loadFont(name)
+	if err != nil {
+		panic(err)
+	}
+	return f
+}
+
+// parseFont reads a FIGlet font: the flf2a header, comment lines, the
+// printable ASCII glyphs, the seven Deutsch glyphs, then code-tagged
+// glyphs for any other character.
+func parseFont(r io.Reader) (*font, error) {
+	var lines []string
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		lines = append(lines, strings.TrimRight(scanner.Text(), "\r"))
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("read font: %w", err)
+	}
+	if len(lines) == 0 {
+		return nil, fmt.Errorf("empty font file")
+	}
+
+	header := strings.Fields(lines[0])
+	if len(header) < 6 || !strings.HasPrefix(header[0], "flf2a") || len(header[0]) == len("flf2a") {
+		return nil, fmt.Errorf("not a FIGlet font: missing flf2a header")
+	}
+	hardblank := []rune(header[0][len("flf2a"):])[0]
+	height, err := strconv.Atoi(header[1])
+	if err != nil || height < 1 {
+		return nil, fmt.Errorf("invalid font height %q", header[1])
+	}
+	comments, err := strconv.Atoi(header[5])
+	if err != nil || comments < 0 {
+		return nil, fmt.Errorf("invalid comment line count %q", header[5])
+	}
+
+	f := &font{height: height, glyphs: make(map[rune][]string)}
+	next := 1 + comments
+	readGlyph := func() ([]string, bool) {
+		if next+height > len(lines) {
+			return nil, false
+		}
+		rows := make([]string, height)
+		width := 0
+		for i := range rows {
+			row := lines[next+i]
+			if row != "" {
+				endmark := row[len(row)-1:]
+				row = strings.TrimRight(row, endmark)
+			}
+			rows[i] = strings.ReplaceAll(row, string(hardblank), " ")
+			if w := displayWidth(rows[i]); w > width {
+				width = w
+			}
+		}
+		for i, row := range rows {
+			rows[i] = row + strings.Repeat(" ", width-displayWidth(row))
+		}
+		next += height
+		return rows, true
+	}
+
+	for c := rune(32); c <= 126; c++ {
+		rows, ok := readGlyph()
+		if !ok {
+			return nil, fmt.Errorf("font ends before the glyph for %q", c)
+		}
+		f.glyphs[c] = rows
+	}
+	for _, c := range deutschCodes {
+		rows, ok := readGlyph()
+		if !ok {
+			break
+		}
+		f.glyphs[c] = rows
+	}
+	for next < len(lines) {
+		if strings.TrimSpace(lines[next]) == "" {
+			next++
+			continue
+		}
+		code, ok := parseCodeTag(lines[next])
+		if !ok {
+			return nil, fmt.Errorf("line %d: expected a character code, got %q", next+1, lines[next])
+		}
+		next++
+		rows, ok := readGlyph()
+		if !ok {
+			return nil, fmt.Errorf("font ends inside the glyph for code %d", code)
+		}
+		if code >= 0 {
+			f.glyphs[rune(code)] = rows
+		}
+	}
+	return f, nil
+}
+
+// parseCodeTag reads the character code that introduces a code-tagged
+// glyph, in decimal, octal (leading 0) or hex (leading 0x).
+func parseCodeTag(line string) (int64, bool) {
+	fields := strings.Fields(line)
+	if len(fields) == 0 {
+		return 0, false
+	}
+	code, err := strconv.ParseInt(fields[0], 0, 32)
+	return code, err == nil
+}
+
+// render draws s in the font, one string per row.
+func (f *font) render(s string) []string {
+	rows := make([]string, f.height)
+	for _, r := range s {
+		for _, g := range f.glyphsFor(r) {
+			for i := range rows {
+				rows[i] += g[i]
+			}
+		}
+	}
+	return rows
+}
+
+// canRender reports whether every character of s has a glyph of its own
+// or through folding, rather than the "?" stand-in.
+func (f *font) canRender(s string) bool {
+	for _, r := range s {
+		if f.fold(r) == nil {
+			return false
+		}
+	}
+	return true
+}
+
+// glyphsFor returns the glyphs that draw r, falling back to "?" for
+// characters the font can't show.
+func (f *font) glyphsFor(r rune) [][]string {
+	if gs := f.fold(r); gs != nil {
+		return gs
+	}
+	if g, ok := f.glyph('?'); ok {
+		return [][]string{g}
+	}
+	return nil
+}
+
+// fold finds r in the font: as itself, in the other case, without its
+// accents ("é" as "e"), or spelled out ("ß" as "ss").
+func (f *font) fold(r rune) [][]string {
+	if g, ok := f.glyph(r); ok {
+		return [][]string{g}
+	}
+	base := []rune(norm.NFD.String(string(r)))[0]
+	if base != r {
+		if g, ok := f.glyph(base); ok {
+			return [][]string{g}
+		}
+	}
+	if spelled, ok := latinFolds[r]; ok {
+		var gs [][]string
+		for _, c := range spelled {
+			g, ok := f.glyph(c)
+			if !ok {
+				return nil
+			}
+			gs = append(gs, g)
+		}
+		return gs
+	}
+	return nil
+}
+
+// glyph looks r up in either case. A glyph with no visible width counts
+// as missing, as fonts leave unsupported characters empty.
+func (f *font) glyph(r rune) ([]string, bool) {
+	for _, c := range []rune{r, unicode.ToUpper(r), unicode.ToLower(r)} {
+		if g, ok := f.glyphs[c]; ok && (c == ' ' || strings.TrimSpace(strings.Join(g, "")) != "") {
+			return g, true
+		}
+	}
+	return nil, false
+}
@@ -0,0 +1,143 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestBlockFont_Coverage(t *testing.T) {
+	for c := rune(32); c <= 126; c++ {
+		if !blockFont.canRender(string(c)) {
+			t.Errorf("block font has no glyph for %q", c)
+		}
+	}
+	for _, title := range []string{"k8s-tools", "web3.js", "v2", "José Ærø", "Straße", "Łódź"} {
+		if !blockFont.canRender(title) {
+			t.Errorf("block font can't draw %q", title)
+		}
+		rows := blockFont.render(title)
+		for _, r := range rows[1:] {
+			if displayWidth(r) != displayWidth(rows[0]) {
+				t.Errorf("%q rows have uneven widths", title)
+			}
+		}
+	}
+	if blockFont.canRender("日本") {
+		t.Error("block font should not claim to draw CJK")
+	}
+	for _, pair := range [][2]string{{"é", "E"}, {"Ö", "O"}, {"ñ", "N"}, {"Ç", "C"}, {"ą", "A"}, {"Ž", "Z"}} {
+		accented, plain := strings.Join(blockFont.render(pair[0]), "\n"), strings.Join(blockFont.render(pair[1]), "\n")
+		if accented == plain {
+			t.Errorf("%s should be drawn with its accent, not as %s", pair[0], pair[1])
+		}
+		if upper := strings.Join(blockFont.render(strings.ToUpper(pair[0])), "\n"); accented != upper {
+			t.Errorf("%s and its capital should share a glyph", pair[0])
+		}
+	}
+	if got, want := len(blockFont.render("ß")[0]), len(blockFont.render("SS")[0]); got != want {
+		t.Errorf("ß should draw as SS")
+	}
+}
+
+// testFont builds a two-row font where every ASCII glyph is its character
+// followed by a hardblank.
+func testFont(extra string) string {
+	var b strings.Builder
+	b.WriteString("flf2a# 2 1 4 -1 1\ncomment line\n")
+	for c := rune(32); c <= 126; c++ {
+		ch := string(c)
+		if c == '@' || c == '#' {
+			ch = "."
+		}
+		b.WriteString(ch + "#@\n" + ch + "#@@\n")
+	}
+	b.WriteString(extra)
+	return b.String()
+}
+
+func TestParseFont(t *testing.T) {
+	deutsch := strings.Repeat("D@\nD@@\n", 7)
+	f, err := parseFont(strings.NewReader(testFont(deutsch + "0x263A  WHITE SMILING FACE\n:)@\n:)@@\n-1\nxx@\nxx@@\n")))
+	if err != nil {
+		t.Fatalf("parseFont returned error: %v", err)
+	}
+	if f.height != 2 {
+		t.Fatalf("height = %d, want 2", f.height)
+	}
+	if got := f.render("Hi"); got[0] != "H i " || got[1] != "H i " {
+		t.Errorf("render(\"Hi\") = %q", got)
+	}
+	if got := f.render("Ö☺"); got[0] != "D:)" {
+		t.Errorf("Deutsch and code-tagged glyphs: got %q", got)
+	}
+	if got := f.render("Ǵ"); got[0] != "G " {
+		t.Errorf("accented letters should fold: got %q", got)
+	}
+	if got := f.render("日"); got[0] != "? " {
+		t.Errorf("unknown characters should draw as ?: got %q", got)
+	}
+
+	// fonts that stop after ASCII are accepted
+	if _, err := parseFont(strings.NewReader(testFont(""))); err != nil {
+		t.Errorf("font without Deutsch glyphs: %v", err)
+	}
+}
+
+func TestParseFont_Errors(t *testing.T) {
+	tests := map[string]string{
+		"empty":      "",
+		"not figlet": "hello world\n",
+		"bad height": "flf2a$ x 1 4 -1 0\n",
+		"truncated":  "flf2a$ 2 1 4 -1 0\n @\n @@\n",
+		"bad tag":    testFont(strings.Repeat("D@\nD@@\n", 7) + "nonsense\nx@\nx@@\n"),
+	}
+	for name, text := range tests {
+		if _, err := parseFont(strings.NewReader(text)); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
+
+func TestLoadFont(t *testing.T) {
+	for _, name := range embeddedFontNames() {
+		if _, err := loadFont(name); err != nil {
+			t.Errorf("embedded font %q: %v", name, err)
+		}
+	}
+	small, err := loadFont("small")
+	if err != nil || small.height != 3 {
+		t.Fatalf("small font: %v", err)
+	}
+
+	path := filepath.Join(t.TempDir(), "mine.flf")
+	if err := os.WriteFile(path, []byte(testFont("")), 0o644); err != nil {
+		t.Fatalf("write font: %v", err)
+	}
+	if f, err := loadFont(path); err != nil || f.height != 2 {
+		t.Fatalf("font file: %v", err)
+	}
+
+	if _, err := loadFont("no-such-font"); err == nil || !strings.Contains(err.Error(), "block, small") {
+		t.Errorf("unknown font should list the embedded fonts, got %v", err)
+	}
+}
+
+func TestParseArgs_Font(t *testing.T) {
+	cfg, err := parseArgs(nil)
+	if err != nil || cfg.font != blockFont {
+		t.Fatalf("default font should be block: %v", err)
+	}
+	path := filepath.Join(t.TempDir(), "mine.flf")
+	if err := os.WriteFile(path, []byte(testFont("")), 0o644); err != nil {
+		t.Fatalf("write font: %v", err)
+	}
+	cfg, err = parseArgs([]string{"--font", path})
+	if err != nil || cfg.font.height != 2 {
+		t.Fatalf("--font %s: %v", path, err)
+	}
+	if _, err := parseArgs([]string{"--font", "nope"}); err == nil {
+		t.Error("expected an error for an unknown font")
+	}
+}
@@ -0,0 +1,273 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strings"
+)
+
+// forgeKinds lists the hosting services metadata can be fetched from.
+var forgeKinds = []string{"github", "gitlab", "gitea"}
+
+// forgeMetadata is what a hosting service knows about a repository that
+// git itself does not.
+type forgeMetadata struct {
+	description string
+	stars       int
+	license     string
+	language    string
+}
+
+// metadataProvider fetches forgeMetadata for a repository path such as
+// "owner/repo" or "group/sub/repo".
+type metadataProvider interface {
+	fetch(ctx context.Context, repo string) (forgeMetadata, error)
+}
+
+// forgeEndpoint maps a self-hosted instance to its kind, e.g.
+// "gitlab=https://git.example.com". Remotes on the URL's host use it.
+type forgeEndpoint struct {
+	kind    string
+	baseURL string
+}
+
+func (e forgeEndpoint) host() string {
+	u, err := url.Parse(e.baseURL)
+	if err != nil {
+		return ""
+	}
+	return u.Hostname()
+}
+
+// parseForgeEndpoint parses a --forge value of the form KIND=URL.
+func parseForgeEndpoint(s string) (forgeEndpoint, error) {
+	kind, base, ok := strings.Cut(s, "=")
+	kind = strings.ToLower(strings.TrimSpace(kind))
+	base = strings.TrimRight(strings.TrimSpace(base), "/")
+	if !ok || !containsString(forgeKinds, kind) {
+		return forgeEndpoint{}, fmt.Errorf("invalid forge %q, expected KIND=URL with KIND one of: %s", s, strings.Join(forgeKinds, ", "))
+	}
+	e := forgeEndpoint{kind: kind, baseURL: base}
+	if u, err := url.Parse(base); err != nil || u.Scheme == "" || e.host() == "" {
+		return forgeEndpoint{}, fmt.Errorf("invalid forge URL %q", base)
+	}
+	return e, nil
+}
+
+// knownForges are the public instances recognized without configuration.
+var knownForges = map[string]forgeEndpoint{
+	"github.com":   {kind: "github", baseURL: "https://github.com"},
+	"gitlab.com":   {kind: "gitlab", baseURL: "https://gitlab.com"},
+	"gitea.com":    {kind: "gitea", baseURL: "https://gitea.com"},
+	"codeberg.org": {kind: "gitea", baseURL: "https://codeberg.org"},
+}
+
+// selectProvider picks the provider for a remote's host. Configured
+// endpoints take precedence over the known public instances; remotes on
+// any other host, and local remotes, have no provider.
+func selectProvider(remote remoteURL, endpoints []forgeEndpoint) (metadataProvider, string, bool) {
+	if remote.host == "" {
+		return nil, "", false
+	}
+	host := strings.ToLower(remote.host)
+	for _, e := range endpoints {
+		if strings.EqualFold(e.host(), host) {
+			return newProvider(e), e.kind, true
+		}
+	}
+	if e, ok := knownForges[host]; ok {
+		return newProvider(e), e.kind, true
+	}
+	return nil, "", false
+}
+
+func newProvider(e forgeEndpoint) metadataProvider {
+	switch e.kind {
+	case "gitlab":
+		return gitlabProvider{baseURL: e.baseURL + "/api/v4", token: os.Getenv("GITLAB_TOKEN")}
+	case "gitea":
+		return giteaProvider{baseURL: e.baseURL + "/api/v1", token: os.Getenv("GITEA_TOKEN")}
+	default:
+		// github.com tokens stay on github.com; Enterprise Server hosts take
+		// their own, following the gh CLI's variables
+		api := e.baseURL + "/api/v3"
+		token := firstEnv("GH_ENTERPRISE_TOKEN", "GITHUB_ENTERPRISE_TOKEN")
+		if e.host() == "github.com" {
+			api = "https://api.github.com"
+			token = firstEnv("GITHUB_TOKEN", "GH_TOKEN")
+		}
+		return githubProvider{baseURL: api, host: e.host(), token: token}
+	}
+}
+
+// firstEnv returns the first of the named environment variables that is set.
+func firstEnv(names ...string) string {
+	for _, name := range names {
+		if v := os.Getenv(name); v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+// githubProvider reads everything from a single repository request. Without
+// a token in the environment it borrows the gh CLI's login, so private
+// repositories and the higher rate limit keep working for gh users.
+type githubProvider struct {
+	baseURL string
+	host    string
+	token   string
+}
+
+// ghAuthToken returns the token "gh auth login" stored for host, or "" when
+// gh is not installed or not logged in.
+func ghAuthToken(ctx context.Context, host string) string {
+	out, err := runCommand(ctx, "", "gh", "auth", "token", "--hostname", host)
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(out))
+}
+
+func (p githubProvider) fetch(ctx context.Context, repo string) (forgeMetadata, error) {
+	var resp struct {
+		Description string `json:"description"`
+		Stars       int    `json:"stargazers_count"`
+		Language    string `json:"language"`
+		License     *struct {
+			Name string `json:"name"`
+			SPDX string `json:"spdx_id"`
+		} `json:"license"`
+	}
+	header := http.Header{"Accept": {"application/vnd.github+json"}}
+	token := p.token
+	if token == "" {
+		token = ghAuthToken(ctx, p.host)
+	}
+	if token != "" {
+		header.Set("Authorization", "Bearer "+token)
+	}
+	if err := getJSON(ctx, p.baseURL+"/repos/"+repo, header, &resp); err != nil {
+		return forgeMetadata{}, err
+	}
+	m := forgeMetadata{description: resp.Description, stars: resp.Stars, language: resp.Language}
+	if resp.License != nil {
+		m.license = resp.License.SPDX
+		if m.license == "" || m.license == "NOASSERTION" {
+			m.license = resp.License.Name
+		}
+	}
+	return m, nil
+}
+
+// gitlabProvider reads the project, then its language breakdown, which
+// GitLab only serves separately.
+type gitlabProvider struct {
+	baseURL string
+	token   string
+}
+
+func (p gitlabProvider) fetch(ctx context.Context, repo string) (forgeMetadata, error) {
+	var resp struct {
+		Description string `json:"description"`
+		Stars       int    `json:"star_count"`
+		License     *struct {
+			Name string `json:"name"`
+		} `json:"license"`
+	}
+	header := http.Header{}
+	if p.token != "" {
+		header.Set("PRIVATE-TOKEN", p.token)
+	}
+	project := p.baseURL + "/projects/" + url.PathEscape(repo)
+	if err := getJSON(ctx, project+"?license=true", header, &resp); err != nil {
+		return forgeMetadata{}, err
+	}
+	m := forgeMetadata{description: resp.Description, stars: resp.Stars}
+	if resp.License != nil {
+		m.license = resp.License.Name
+	}
+
+	var languages map[string]float64 // name → percentage
+	if err := getJSON(ctx, project+"/languages", header, &languages); err != nil {
+		return forgeMetadata{}, err
+	}
+	m.language = topLanguage(languages)
+	return m, nil
+}
+
+// giteaProvider reads everything from a single repository request.
+// Gitea and Forgejo share this API.
+type giteaProvider struct {
+	baseURL string
+	token   string
+}
+
+func (p giteaProvider) fetch(ctx context.Context, repo string) (forgeMetadata, error) {
+	var resp struct {
+		Description string   `json:"description"`
+		Stars       int      `json:"stars_count"`
+		Language    string   `json:"language"`
+		Licenses    []string `json:"licenses"`
+	}
+	header := http.Header{}
+	if p.token != "" {
+		header.Set("Authorization", "token "+p.token)
+	}
+	if err := getJSON(ctx, p.baseURL+"/repos/"+repo, header, &resp); err != nil {
+		return forgeMetadata{}, err
+	}
+	return forgeMetadata{
+		description: resp.Description,
+		stars:       resp.Stars,
+		language:    resp.Language,
+		license:     strings.Join(resp.Licenses, " OR "),
+	}, nil
+}
+
+// topLanguage returns the language with the largest share, breaking ties
+// by name so the result is stable.
+func topLanguage(shares map[string]float64) string {
+	names := make([]string, 0, len(shares))
+	for name := range shares {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	top := ""
+	for _, name := range names {
+		if top == "" || shares[name] > shares[top] {
+			top = name
+		}
+	}
+	return top
+}
+
+// getJSON performs a GET request and decodes a JSON response into v.
+func getJSON(ctx context.Context, rawURL string, header http.Header, v interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, rawURL, nil)
+	if err != nil {
+		return err
+	}
+	for k, vs := range header {
+		req.Header[k] = vs
+	}
+	req.Header.Set("User-Agent", "gitcredits/"+version)
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("GET %s: %s", req.URL.Path, resp.Status)
+	}
+	if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+		return fmt.Errorf("GET %s: %w", req.URL.Path, err)
+	}
+	return nil
+}
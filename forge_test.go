@@ -0,0 +1,196 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// forgeStandIn serves canned JSON by request URI and records the headers of
+// the last request.
+func forgeStandIn(t *testing.T, responses map[string]string) (*httptest.Server, *http.Header) {
+	t.Helper()
+	var last http.Header
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		last = r.Header.Clone()
+		body, ok := responses[r.URL.RequestURI()]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(body))
+	}))
+	t.Cleanup(srv.Close)
+	return srv, &last
+}
+
+func TestGithubProvider(t *testing.T) {
+	srv, header := forgeStandIn(t, map[string]string{
+		"/api/v3/repos/acme/rocket": `{"description":"Fast rockets","stargazers_count":1234,"language":"Go","license":{"name":"MIT License","spdx_id":"MIT"}}`,
+	})
+	t.Setenv("GH_ENTERPRISE_TOKEN", "secret")
+	t.Setenv("GITHUB_TOKEN", "public")
+
+	p := newProvider(forgeEndpoint{kind: "github", baseURL: srv.URL})
+	m, err := p.fetch(context.Background(), "acme/rocket")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := forgeMetadata{description: "Fast rockets", stars: 1234, license: "MIT", language: "Go"}
+	if m != want {
+		t.Errorf("got %+v, want %+v", m, want)
+	}
+	if got := header.Get("Authorization"); got != "Bearer secret" {
+		t.Errorf("Authorization = %q, want the enterprise token", got)
+	}
+
+	// github.com tokens go to github.com only
+	if got := newProvider(knownForges["github.com"]).(githubProvider).token; got != "public" {
+		t.Errorf("github.com token = %q, want GITHUB_TOKEN", got)
+	}
+}
+
+func TestGithubProvider_GhAuthFallback(t *testing.T) {
+	srv, header := forgeStandIn(t, map[string]string{
+		"/api/v3/repos/acme/rocket": `{"description":"Fast rockets"}`,
+	})
+	t.Setenv("GH_ENTERPRISE_TOKEN", "")
+	t.Setenv("GITHUB_ENTERPRISE_TOKEN", "")
+
+	// a stand-in gh that reports the host it was asked about
+	bin := t.TempDir()
+	script := "#!/bin/sh\n[ \"$1 $2 $3\" = \"auth token --hostname\" ] && echo \"token-for-$4\"\n"
+	if err := os.WriteFile(filepath.Join(bin, "gh"), []byte(script), 0o755); err != nil {
+		t.Fatalf("write gh: %v", err)
+	}
+	t.Setenv("PATH", bin+string(os.PathListSeparator)+os.Getenv("PATH"))
+
+	p := newProvider(forgeEndpoint{kind: "github", baseURL: srv.URL})
+	if _, err := p.fetch(context.Background(), "acme/rocket"); err != nil {
+		t.Fatal(err)
+	}
+	if got := header.Get("Authorization"); got != "Bearer token-for-127.0.0.1" {
+		t.Errorf("Authorization = %q, want the gh login token", got)
+	}
+
+	// without gh the request goes out unauthenticated
+	t.Setenv("PATH", t.TempDir())
+	if _, err := p.fetch(context.Background(), "acme/rocket"); err != nil {
+		t.Fatal(err)
+	}
+	if got := header.Get("Authorization"); got != "" {
+		t.Errorf("Authorization = %q, want none", got)
+	}
+}
+
+func TestGitlabProvider(t *testing.T) {
+	srv, header := forgeStandIn(t, map[string]string{
+		"/api/v4/projects/group%2Fsub%2Frocket?license=true": `{"description":"Rockets","star_count":42,"license":{"name":"Apache License 2.0"}}`,
+		"/api/v4/projects/group%2Fsub%2Frocket/languages":    `{"Shell":20.5,"Go":72.1,"Makefile":7.4}`,
+	})
+	t.Setenv("GITLAB_TOKEN", "secret")
+
+	p := newProvider(forgeEndpoint{kind: "gitlab", baseURL: srv.URL})
+	m, err := p.fetch(context.Background(), "group/sub/rocket")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := forgeMetadata{description: "Rockets", stars: 42, license: "Apache License 2.0", language: "Go"}
+	if m != want {
+		t.Errorf("got %+v, want %+v", m, want)
+	}
+	if got := header.Get("Private-Token"); got != "secret" {
+		t.Errorf("PRIVATE-TOKEN = %q", got)
+	}
+}
+
+func TestGiteaProvider(t *testing.T) {
+	srv, _ := forgeStandIn(t, map[string]string{
+		"/api/v1/repos/acme/rocket": `{"description":"Rockets","stars_count":7,"language":"Rust","licenses":["MIT","Apache-2.0"]}`,
+	})
+
+	p := newProvider(forgeEndpoint{kind: "gitea", baseURL: srv.URL})
+	m, err := p.fetch(context.Background(), "acme/rocket")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := forgeMetadata{description: "Rockets", stars: 7, license: "MIT OR Apache-2.0", language: "Rust"}
+	if m != want {
+		t.Errorf("got %+v, want %+v", m, want)
+	}
+}
+
+func TestProviderHTTPError(t *testing.T) {
+	srv, _ := forgeStandIn(t, nil)
+
+	p := newProvider(forgeEndpoint{kind: "github", baseURL: srv.URL})
+	_, err := p.fetch(context.Background(), "acme/missing")
+	if err == nil || !strings.Contains(err.Error(), "404") {
+		t.Errorf("expected 404 error, got %v", err)
+	}
+}
+
+func TestSelectProvider(t *testing.T) {
+	endpoints := []forgeEndpoint{{kind: "gitlab", baseURL: "https://git.example.com"}}
+	tests := []struct {
+		remote string
+		kind   string
+		ok     bool
+	}{
+		{"git@github.com:acme/rocket.git", "github", true},
+		{"https://gitlab.com/group/rocket", "gitlab", true},
+		{"https://codeberg.org/acme/rocket", "gitea", true},
+		{"ssh://git@git.example.com:2222/team/rocket.git", "gitlab", true},
+		{"https://unknown.example.org/acme/rocket", "", false},
+		{"/srv/git/rocket.git", "", false},
+	}
+	for _, tt := range tests {
+		remote, _ := parseRemoteURL(tt.remote)
+		_, kind, ok := selectProvider(remote, endpoints)
+		if kind != tt.kind || ok != tt.ok {
+			t.Errorf("selectProvider(%q) = %q, %v; want %q, %v", tt.remote, kind, ok, tt.kind, tt.ok)
+		}
+	}
+}
+
+func TestParseForgeEndpoint(t *testing.T) {
+	e, err := parseForgeEndpoint("GitLab=https://git.example.com/")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if e.kind != "gitlab" || e.baseURL != "https://git.example.com" || e.host() != "git.example.com" {
+		t.Errorf("got %+v", e)
+	}
+	for _, bad := range []string{"gitlab", "bitbucket=https://x.org", "gitea=git.example.com"} {
+		if _, err := parseForgeEndpoint(bad); err == nil {
+			t.Errorf("parseForgeEndpoint(%q): expected error", bad)
+		}
+	}
+}
+
+func TestGetRepoInfo_ForgeMetadata(t *testing.T) {
+	srv, _ := forgeStandIn(t, map[string]string{
+		"/api/v1/repos/acme/rocket": `{"description":"From the forge","stars_count":99,"language":"Go","licenses":["MIT"]}`,
+	})
+	repoDir := setupTestRepo(t)
+	runInDir(t, repoDir, "git", "remote", "add", "origin", srv.URL+"/acme/rocket.git")
+
+	opts := repoOptions{forges: []forgeEndpoint{{kind: "gitea", baseURL: srv.URL}}}
+	info, err := getRepoInfo(context.Background(), repoDir, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.stars != 99 || info.language != "Go" || info.license != "MIT" || info.description != "From the forge" {
+		t.Errorf("forge metadata not applied: %+v", info)
+	}
+
+	opts.offline = true
+	if info, _ = getRepoInfo(context.Background(), repoDir, opts); info.stars != 0 {
+		t.Errorf("offline fetched stars: %d", info.stars)
+	}
+}
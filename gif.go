@@ -8,7 +8,7 @@ import (
 	"strings"
 )
 
-func generateGIF(outputPath, theme, dir string, lines []string, cardCount int) error {
+func generateGIF(outputPath, theme, dir string, extraArgs []string, lines []string, cardCount int) error {
 	vhsPath, err := exec.LookPath("vhs")
 	if err != nil {
 		return fmt.Errorf("vhs is required for GIF output. Install: brew install vhs")
@@ -58,9 +58,13 @@ func generateGIF(outputPath, theme, dir string, lines []string, cardCount int) e
 	if theme != "default" {
 		cmdParts = append(cmdParts, "--theme", theme)
 	}
+	cmdParts = append(cmdParts, extraArgs...)
 	if dir != "" {
 		cmdParts = append(cmdParts, dir)
 	}
+	for i, part := range cmdParts {
+		cmdParts[i] = shellQuote(part)
+	}
 	cmd := strings.Join(cmdParts, " ")
 	tape.WriteString(fmt.Sprintf("Type %q\n", cmd))
 	tape.WriteString("Enter\n")
@@ -115,3 +119,11 @@ func generateGIF(outputPath, theme, dir string, lines []string, cardCount int) e
 
 	return nil
 }
+
+// shellQuote quotes s for the shell VHS types the command into.
+func shellQuote(s string) string {
+	if s != "" && !strings.ContainsAny(s, " \t\n'\"\\$`!*?[]{}()<>|&;#~") {
+		return s
+	}
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
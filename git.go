@@ -1,32 +1,105 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"os"
 	"os/exec"
 	"path/filepath"
-	"sort"
-	"strconv"
 	"strings"
+	"time"
 )
 
 type repoInfo struct {
 	name         string
+	path         string // absolute repository directory
 	description  string
 	totalCommits int
 	contributors []contributor
-	highlights   []string
+	highlights   []highlight
+	bots         []contributor // automation accounts, credited separately
 	stars        int
 	license      string
-	language     string
+	language     string          // primary language
+	languages    []languageShare // breakdown by tracked bytes, largest first
+	roles        []roleCredit
+	window       string          // e.g. "Q3 2026" when the history is time-scoped
+	timeline     timeline        // first and last commit, releases, busiest year
+	failures     []sourceFailure // data sources that could not be collected
+	release      string          // e.g. "v1.3.0" when crediting a revision range
+	releaseNote  string          // annotated tag subject for the release
+}
+
+// repoOptions controls which history getRepoInfo collects.
+type repoOptions struct {
+	roles       []trailerRole   // trailer-to-department mapping for role sections
+	since       string          // only count commits after this date (git date syntax)
+	until       string          // only count commits before this date
+	rev         string          // revision or range to credit, e.g. "v1.2.0..v1.3.0"
+	paths       []string        // pathspecs limiting history to part of a monorepo
+	rankBy      string          // contributor ordering: commits, lines, files or score
+	types       []string        // Conventional Commit types shown as highlights
+	bots        string          // bot handling: hide, separate (default) or include
+	botPatterns []string        // extra glob patterns identifying bot accounts
+	forges      []forgeEndpoint // self-hosted forge instances by host
+	offline     bool            // skip forge metadata requests entirely
+
+	descriptionOrder []string // description sources to try, see descriptionSources
+}
+
+// revisionArgs selects the commits every history query should cover. It
+// ends with the pathspecs, so callers must append it last.
+func (o repoOptions) revisionArgs() []string {
+	var args []string
+	if o.since != "" {
+		args = append(args, "--since="+windowBound(o.since, false))
+	}
+	if o.until != "" {
+		args = append(args, "--until="+windowBound(o.until, true))
+	}
+	rev := o.rev
+	if rev == "" {
+		rev = "HEAD"
+	}
+	args = append(args, rev)
+	if len(o.paths) > 0 {
+		args = append(append(args, "--"), o.paths...)
+	}
+	return args
+}
+
+// pathTitle names the movie after the subdirectories being credited,
+// e.g. "api" for --path services/api.
+func pathTitle(paths []string) string {
+	var names []string
+	for _, p := range paths {
+		p = strings.TrimRight(filepath.ToSlash(filepath.Clean(p)), "/")
+		if p == "" || p == "." {
+			continue
+		}
+		names = append(names, filepath.Base(p))
+	}
+	return strings.Join(names, " + ")
 }
 
 type contributor struct {
-	name    string
-	commits int
+	name       string      // canonical display name
+	emails     []string    // every address this person committed with
+	commits    int         // authored plus co-authored commits across all aliases
+	authored   int         // commits where this person is the git author
+	coAuthored int         // commits credited through Co-authored-by trailers
+	newcomer   bool        // first contribution falls inside the credited range
+	added      int         // lines added in authored commits
+	deleted    int         // lines deleted in authored commits
+	files      int         // distinct files touched in authored commits
+	repos      []repoShare // per-repository breakdown when aggregating repos
+	first      time.Time   // earliest authored commit
+	last       time.Time   // latest authored commit
+	debut      string      // subject of the earliest authored commit
 }
 
-func getRepoInfo(dir string) (repoInfo, error) {
+func getRepoInfo(ctx context.Context, dir string, opts repoOptions) (repoInfo, error) {
 	info := repoInfo{}
 	repoDir := dir
 	if repoDir == "" {
@@ -50,156 +123,269 @@ func getRepoInfo(dir string) (repoInfo, error) {
 		return info, fmt.Errorf("repository path %q is not a directory", absRepoDir)
 	}
 
-	info.name = filepath.Base(absRepoDir)
+	layout, err := resolveRepoLayout(ctx, absRepoDir)
+	if err != nil {
+		return info, err
+	}
+	if opts.rev != "" {
+		if err := verifyRevision(ctx, absRepoDir, opts.rev); err != nil {
+			return info, err
+		}
+	}
+	info.path = absRepoDir
+	info.name = layout.projectName(ctx, absRepoDir)
+	if title := pathTitle(opts.paths); title != "" {
+		info.name = title
+	}
+	info.window = windowLabel(opts.since, opts.until)
+	if opts.rev != "" {
+		info.release = releaseName(opts.rev)
+		info.releaseNote = tagSubject(ctx, absRepoDir, info.release)
+	}
 
-	if desc, err := os.ReadFile(filepath.Join(absRepoDir, ".git", "description")); err == nil {
+	var gitDescription string
+	if desc, err := os.ReadFile(filepath.Join(layout.commonDir, "description")); err == nil {
 		d := strings.TrimSpace(string(desc))
-		if d != "" && d != "Unnamed repository; edit this file 'description' to name the repository." {
-			info.description = d
+		if d != "Unnamed repository; edit this file 'description' to name the repository." {
+			gitDescription = d
 		}
 	}
 
-	if info.description == "" {
-		if out, err := runCommand(absRepoDir, "gh", "repo", "view", "--json", "description", "-q", ".description"); err == nil {
-			d := strings.TrimSpace(string(out))
-			if d != "" {
-				info.description = d
-			}
+	// Independent collectors run concurrently; each writes only its own
+	// variables, which are merged into info once all have finished.
+	var (
+		history   *historyCollector
+		license   string
+		languages []languageShare
+		releases  int
+		meta      forgeMetadata
+	)
+	tree := treeRevision(ctx, absRepoDir, opts.rev)
+	var sources []source
+	if opts.rev != "" || hasCommits(ctx, absRepoDir) {
+		// an unborn HEAD is an empty history, not a set of broken sources
+		sources = []source{
+			{name: "git history", run: func(ctx context.Context) error {
+				h, err := collectHistory(ctx, absRepoDir, opts)
+				history = h
+				return err
+			}},
+			{name: "license file", run: func(ctx context.Context) error {
+				l, err := detectLicense(ctx, absRepoDir, tree)
+				license = l
+				return err
+			}},
+			{name: "releases", run: func(ctx context.Context) error {
+				n, err := releaseCount(ctx, absRepoDir, opts)
+				releases = n
+				return err
+			}},
+			{name: "languages", run: func(ctx context.Context) error {
+				l, err := detectLanguages(ctx, absRepoDir, tree, opts.paths)
+				languages = l
+				return err
+			}},
 		}
 	}
-
-	if out, err := runCommand(absRepoDir, "git", "rev-list", "--count", "HEAD"); err == nil {
-		if n, err := strconv.Atoi(strings.TrimSpace(string(out))); err == nil {
-			info.totalCommits = n
+	if remote, ok := originRemote(ctx, absRepoDir); ok && !opts.offline {
+		if provider, kind, ok := selectProvider(remote, opts.forges); ok {
+			sources = append(sources, source{name: kind + " metadata", timeout: metadataTimeout, run: func(ctx context.Context) error {
+				m, err := provider.fetch(ctx, remote.path)
+				meta = m
+				return err
+			}})
 		}
 	}
+	info.failures = runSources(ctx, sources)
+	if err := ctx.Err(); err != nil {
+		return info, err
+	}
 
-	if out, err := runCommand(absRepoDir, "git", "shortlog", "-sn", "--no-merges", "HEAD"); err == nil {
-		lines := strings.Split(strings.TrimSpace(string(out)), "\n")
-		for _, line := range lines {
-			line = strings.TrimSpace(line)
-			if line == "" {
-				continue
+	// the forge is only a fallback for the license and language; local ones
+	// win
+	info.stars = meta.stars
+	info.license = license
+	if info.license == "" {
+		info.license = meta.license
+	}
+	info.languages = languages
+	info.language = meta.language
+	if len(languages) > 0 {
+		info.language = languages[0].name
+	}
+	info.description = resolveDescription(ctx, absRepoDir, tree, opts.descriptionOrder, gitDescription, meta.description)
+
+	if history != nil {
+		roleResolvers := history.resolveTrailers(ctx, absRepoDir)
+		info.totalCommits = history.totalCommits
+		info.highlights = history.highlights
+		info.timeline = history.timeline
+
+		info.contributors = history.authors.contributors()
+		rankContributors(info.contributors, opts.rankBy)
+		if opts.bots != "include" {
+			info.contributors, info.bots = splitBots(info.contributors, opts.botPatterns)
+			if opts.bots == "hide" {
+				info.bots = nil
 			}
-			parts := strings.SplitN(line, "\t", 2)
-			if len(parts) == 2 {
-				n, _ := strconv.Atoi(strings.TrimSpace(parts[0]))
-				info.contributors = append(info.contributors, contributor{
-					name:    strings.TrimSpace(parts[1]),
-					commits: n,
-				})
+		}
+		if opts.rev != "" {
+			markNewcomers(info.contributors, priorIdentities(ctx, absRepoDir, opts.rev, opts.paths))
+		}
+		if opts.rev != "" || opts.since != "" || opts.until != "" {
+			// the credited history may start after people did
+			starts := careerStarts(ctx, absRepoDir, opts.rev, opts.paths)
+			applyCareerStarts(info.contributors, starts)
+			applyCareerStarts(info.bots, starts)
+		}
+		for i, role := range opts.roles {
+			if people := roleResolvers[i].contributors(); len(people) > 0 {
+				info.roles = append(info.roles, roleCredit{title: role.title, people: people})
 			}
 		}
 	}
+	info.timeline.releases = releases
+
+	return info, nil
+}
 
-	sort.Slice(info.contributors, func(i, j int) bool {
-		return info.contributors[i].commits > info.contributors[j].commits
-	})
+// repoLayout is where git says a repository lives, which may differ from
+// the directory gitcredits was pointed at.
+type repoLayout struct {
+	topLevel  string // root of the working tree; empty for bare repositories
+	gitDir    string // git dir of this worktree
+	commonDir string // git dir shared by all worktrees
+	bare      bool
+}
 
-	if out, err := runCommand(absRepoDir, "git", "log", "--oneline", "--no-merges", "-50", "--format=%s"); err == nil {
-		lines := strings.Split(strings.TrimSpace(string(out)), "\n")
-		for _, line := range lines {
-			line = strings.TrimSpace(line)
-			if strings.HasPrefix(line, "feat:") || strings.HasPrefix(line, "fix:") {
-				msg := line
-				if strings.HasPrefix(line, "feat: ") {
-					msg = line[6:]
-				} else if strings.HasPrefix(line, "fix: ") {
-					msg = line[5:]
-				}
-				info.highlights = append(info.highlights, msg)
-				if len(info.highlights) >= 8 {
-					break
-				}
+// linkedWorktree reports whether the layout is a secondary worktree created
+// by "git worktree add", whose .git is a file pointing elsewhere.
+func (l repoLayout) linkedWorktree() bool {
+	return l.gitDir != l.commonDir
+}
+
+// hasCommits reports whether HEAD points at a commit, which it does not in
+// a freshly initialised repository.
+func hasCommits(ctx context.Context, dir string) bool {
+	_, err := runCommand(ctx, dir, "git", "rev-parse", "--verify", "--quiet", "HEAD^{commit}")
+	return err == nil
+}
+
+func resolveRepoLayout(ctx context.Context, dir string) (repoLayout, error) {
+	// --path-format=absolute needs git 2.31, so resolve relative paths here
+	out, err := runCommand(ctx, dir, "git", "rev-parse",
+		"--is-bare-repository", "--git-dir", "--git-common-dir")
+	if err != nil {
+		if ctx.Err() != nil {
+			return repoLayout{}, ctx.Err()
+		}
+		return repoLayout{}, fmt.Errorf("resolve git repository at %q: %w", dir, err)
+	}
+	fields := strings.Split(strings.TrimSpace(string(out)), "\n")
+	if len(fields) != 3 {
+		return repoLayout{}, fmt.Errorf("unexpected git rev-parse output for %q", dir)
+	}
+	abs := func(p string) string {
+		if !filepath.IsAbs(p) {
+			p = filepath.Join(dir, p)
+		}
+		return filepath.Clean(p)
+	}
+	layout := repoLayout{
+		bare:      fields[0] == "true",
+		gitDir:    abs(fields[1]),
+		commonDir: abs(fields[2]),
+	}
+	if !layout.bare {
+		out, err := runCommand(ctx, dir, "git", "rev-parse", "--show-toplevel")
+		if err != nil {
+			if ctx.Err() != nil {
+				return repoLayout{}, ctx.Err()
 			}
+			return repoLayout{}, fmt.Errorf("resolve top-level directory of %q: %w", dir, err)
 		}
+		layout.topLevel = filepath.Clean(strings.TrimSpace(string(out)))
 	}
+	return layout, nil
+}
 
-	if out, err := runCommand(absRepoDir, "gh", "repo", "view", "--json", "stargazerCount", "-q", ".stargazerCount"); err == nil {
-		if n, err := strconv.Atoi(strings.TrimSpace(string(out))); err == nil {
-			info.stars = n
+// projectName names the movie. Normally that is the top-level directory;
+// bare repositories and linked worktrees are usually named after a branch
+// or "repo.git", so the origin remote names those when it is available.
+func (l repoLayout) projectName(ctx context.Context, dir string) string {
+	if l.bare || l.linkedWorktree() {
+		if remote, ok := originRemote(ctx, dir); ok {
+			return remote.name()
+		}
+		if l.bare {
+			return strings.TrimSuffix(filepath.Base(l.commonDir), ".git")
 		}
+		if filepath.Base(l.commonDir) == ".git" {
+			return filepath.Base(filepath.Dir(l.commonDir))
+		}
+	}
+	if l.topLevel != "" {
+		return filepath.Base(l.topLevel)
 	}
+	return filepath.Base(dir)
+}
 
-	if out, err := runCommand(absRepoDir, "gh", "repo", "view", "--json", "licenseInfo", "-q", ".licenseInfo.name"); err == nil {
-		l := strings.TrimSpace(string(out))
-		if l != "" {
-			info.license = l
+// checkMailmap resolves "Name <email>" idents through .mailmap, which git
+// does not apply to trailer values on its own.
+func checkMailmap(ctx context.Context, dir string, idents []string) []authorKey {
+	mapped := make(map[string]string)
+	var unique []string
+	for _, id := range idents {
+		if _, ok := mapped[id]; !ok {
+			mapped[id] = id
+			if _, email := parseIdent(id); email != "" {
+				unique = append(unique, id)
+			}
 		}
 	}
-
-	if out, err := runCommand(absRepoDir, "gh", "repo", "view", "--json", "primaryLanguage", "-q", ".primaryLanguage.name"); err == nil {
-		l := strings.TrimSpace(string(out))
-		if l != "" {
-			info.language = l
+	if len(unique) > 0 {
+		cmd := exec.CommandContext(ctx, "git", "check-mailmap", "--stdin")
+		cmd.Dir = dir
+		cmd.Stdin = strings.NewReader(strings.Join(unique, "\n") + "\n")
+		if out, err := cmd.Output(); err == nil {
+			resolved := strings.Split(strings.TrimRight(string(out), "\n"), "\n")
+			if len(resolved) == len(unique) {
+				for i, id := range unique {
+					mapped[id] = resolved[i]
+				}
+			}
 		}
 	}
 
-	return info, nil
+	keys := make([]authorKey, 0, len(idents))
+	for _, id := range idents {
+		name, email := parseIdent(mapped[id])
+		keys = append(keys, authorKey{name: name, email: email})
+	}
+	return keys
 }
 
-func runCommand(dir, name string, args ...string) ([]byte, error) {
-	cmd := exec.Command(name, args...)
+// runCommand runs a command in dir and returns its stdout. Failures carry
+// the first line of stderr, which usually says what went wrong.
+func runCommand(ctx context.Context, dir, name string, args ...string) ([]byte, error) {
+	cmd := exec.CommandContext(ctx, name, args...)
 	cmd.Dir = dir
-	return cmd.Output()
-}
-
-// simple block letter generator for title
-func bigText(s string) []string {
-	letters := map[rune][]string{
-		'A': {"  ██  ", " █  █ ", " ████ ", " █  █ ", " █  █ "},
-		'B': {" ███  ", " █  █ ", " ███  ", " █  █ ", " ███  "},
-		'C': {"  ███ ", " █    ", " █    ", " █    ", "  ███ "},
-		'D': {" ███  ", " █  █ ", " █  █ ", " █  █ ", " ███  "},
-		'E': {" ████ ", " █    ", " ███  ", " █    ", " ████ "},
-		'F': {" ████ ", " █    ", " ███  ", " █    ", " █    "},
-		'G': {"  ███ ", " █    ", " █ ██ ", " █  █ ", "  ███ "},
-		'H': {" █  █ ", " █  █ ", " ████ ", " █  █ ", " █  █ "},
-		'I': {" ███ ", "  █  ", "  █  ", "  █  ", " ███ "},
-		'J': {"  ███ ", "    █ ", "    █ ", " █  █ ", "  ██  "},
-		'K': {" █  █ ", " █ █  ", " ██   ", " █ █  ", " █  █ "},
-		'L': {" █    ", " █    ", " █    ", " █    ", " ████ "},
-		'M': {" █   █ ", " ██ ██ ", " █ █ █ ", " █   █ ", " █   █ "},
-		'N': {" █   █ ", " ██  █ ", " █ █ █ ", " █  ██ ", " █   █ "},
-		'O': {"  ██  ", " █  █ ", " █  █ ", " █  █ ", "  ██  "},
-		'P': {" ███  ", " █  █ ", " ███  ", " █    ", " █    "},
-		'Q': {"  ██  ", " █  █ ", " █  █ ", " █ █  ", "  █ █ "},
-		'R': {" ███  ", " █  █ ", " ███  ", " █ █  ", " █  █ "},
-		'S': {"  ███ ", " █    ", "  ██  ", "    █ ", " ███  "},
-		'T': {" █████ ", "   █   ", "   █   ", "   █   ", "   █   "},
-		'U': {" █  █ ", " █  █ ", " █  █ ", " █  █ ", "  ██  "},
-		'V': {" █  █ ", " █  █ ", " █  █ ", "  ██  ", "  ██  "},
-		'W': {" █   █ ", " █   █ ", " █ █ █ ", " ██ ██ ", " █   █ "},
-		'X': {" █  █ ", " █  █ ", "  ██  ", " █  █ ", " █  █ "},
-		'Y': {" █  █ ", " █  █ ", "  ██  ", "  █   ", "  █   "},
-		'Z': {" ████ ", "   █  ", "  █   ", " █    ", " ████ "},
-		'-': {"      ", "      ", " ──── ", "      ", "      "},
-		' ': {"   ", "   ", "   ", "   ", "   "},
-		'_': {"      ", "      ", "      ", "      ", " ████ "},
-	}
-
-	upper := strings.ToUpper(s)
-	rows := make([]string, 5)
-
-	for _, ch := range upper {
-		letter, ok := letters[ch]
-		if !ok {
-			letter = letters[' ']
-		}
-		for row := 0; row < 5; row++ {
-			rows[row] += letter[row]
-		}
-	}
-
-	return rows
+	out, err := cmd.Output()
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		if msg, _, _ := strings.Cut(strings.TrimSpace(string(exitErr.Stderr)), "\n"); msg != "" {
+			err = fmt.Errorf("%w: %s", err, msg)
+		}
+	}
+	return out, err
 }
 
 func centerText(s string, width int) string {
-	runeLen := len([]rune(s))
-	if runeLen >= width {
+	w := displayWidth(s)
+	if w >= width {
 		return s
 	}
-	pad := (width - runeLen) / 2
+	pad := (width - w) / 2
 	return strings.Repeat(" ", pad) + s
 }
 
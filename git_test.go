@@ -1,6 +1,8 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"os"
 	"os/exec"
 	"path/filepath"
@@ -16,8 +18,8 @@ func TestParseArgs_Defaults(t *testing.T) {
 	if cfg.theme != "default" {
 		t.Fatalf("expected default theme, got %q", cfg.theme)
 	}
-	if cfg.dir != "" {
-		t.Fatalf("expected empty dir, got %q", cfg.dir)
+	if len(cfg.dirs) != 0 {
+		t.Fatalf("expected no dirs, got %q", cfg.dirs)
 	}
 }
 
@@ -26,15 +28,18 @@ func TestParseArgs_WithDirectoryAndFlags(t *testing.T) {
 	if err != nil {
 		t.Fatalf("parseArgs returned error: %v", err)
 	}
-	if cfg.theme != "matrix" || cfg.output != "out.gif" || cfg.dir != "/tmp/repo" {
+	if cfg.theme != "matrix" || cfg.output != "out.gif" || len(cfg.dirs) != 1 || cfg.dirs[0] != "/tmp/repo" {
 		t.Fatalf("unexpected config: %+v", cfg)
 	}
 }
 
-func TestParseArgs_RejectsMultipleDirectories(t *testing.T) {
-	_, err := parseArgs([]string{"repo1", "repo2"})
-	if err == nil || !strings.Contains(err.Error(), "only one target directory") {
-		t.Fatalf("expected multiple directory error, got %v", err)
+func TestParseArgs_MultipleDirectories(t *testing.T) {
+	cfg, err := parseArgs([]string{"repo1", "--title", "ACME PLATFORM", "repo2"})
+	if err != nil {
+		t.Fatalf("parseArgs returned error: %v", err)
+	}
+	if strings.Join(cfg.dirs, ",") != "repo1,repo2" || cfg.title != "ACME PLATFORM" {
+		t.Fatalf("unexpected config: %+v", cfg)
 	}
 }
 
@@ -49,7 +54,7 @@ func TestGetRepoInfo_CurrentDirectory(t *testing.T) {
 		t.Fatalf("chdir: %v", err)
 	}
 
-	info, err := getRepoInfo("")
+	info, err := getRepoInfo(context.Background(), "", repoOptions{})
 	if err != nil {
 		t.Fatalf("getRepoInfo returned error: %v", err)
 	}
@@ -68,7 +73,7 @@ func TestGetRepoInfo_TargetDirectory(t *testing.T) {
 		t.Fatalf("getwd: %v", err)
 	}
 
-	info, err := getRepoInfo(repoDir)
+	info, err := getRepoInfo(context.Background(), repoDir, repoOptions{})
 	if err != nil {
 		t.Fatalf("getRepoInfo returned error: %v", err)
 	}
@@ -80,8 +85,24 @@ func TestGetRepoInfo_TargetDirectory(t *testing.T) {
 	}
 }
 
+func TestGetRepoInfo_NoCommits(t *testing.T) {
+	dir := t.TempDir()
+	runInDir(t, dir, "git", "init")
+
+	info, err := getRepoInfo(context.Background(), dir, repoOptions{offline: true})
+	if err != nil {
+		t.Fatalf("getRepoInfo returned error: %v", err)
+	}
+	if len(info.failures) != 0 {
+		t.Fatalf("expected an empty history without warnings, got %+v", info.failures)
+	}
+	if info.totalCommits != 0 || len(info.contributors) != 0 {
+		t.Fatalf("expected no credits, got %+v", info)
+	}
+}
+
 func TestGetRepoInfo_InvalidDirectory(t *testing.T) {
-	_, err := getRepoInfo("/definitely/not/a/repo")
+	_, err := getRepoInfo(context.Background(), "/definitely/not/a/repo", repoOptions{})
 	if err == nil {
 		t.Fatal("expected error for invalid directory")
 	}
@@ -110,3 +131,181 @@ func runInDir(t *testing.T, dir string, name string, args ...string) {
 		t.Fatalf("%s %v failed: %v\n%s", name, args, err, string(out))
 	}
 }
+
+func commitAs(t *testing.T, dir, name, email, message string) {
+	t.Helper()
+	commitWithEnv(t, dir, message,
+		"GIT_AUTHOR_NAME="+name, "GIT_AUTHOR_EMAIL="+email,
+		"GIT_COMMITTER_NAME="+name, "GIT_COMMITTER_EMAIL="+email,
+	)
+}
+
+func commitAt(t *testing.T, dir, date, message string) {
+	t.Helper()
+	commitWithEnv(t, dir, message, "GIT_AUTHOR_DATE="+date, "GIT_COMMITTER_DATE="+date)
+}
+
+func commitWithEnv(t *testing.T, dir, message string, env ...string) {
+	t.Helper()
+	cmd := exec.Command("git", "commit", "--allow-empty", "-m", message)
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(), env...)
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("git commit %q failed: %v\n%s", message, err, string(out))
+	}
+}
+
+func TestParseArgs_TimeWindow(t *testing.T) {
+	cfg, err := parseArgs([]string{"--since", "2026-07-01", "--until", "2026-09-30"})
+	if err != nil {
+		t.Fatalf("parseArgs returned error: %v", err)
+	}
+	opts := cfg.repoOptions()
+	if opts.since != "2026-07-01" || opts.until != "2026-09-30" {
+		t.Fatalf("unexpected window: %+v", opts)
+	}
+}
+
+func TestGetRepoInfo_TimeWindow(t *testing.T) {
+	repoDir := setupTestRepo(t)
+	commitAt(t, repoDir, "2026-08-15T12:00:00", "feat: summer feature")
+	commitAt(t, repoDir, "2026-09-30T18:00:00", "fix: last day of the quarter")
+	commitAt(t, repoDir, "2026-10-02T12:00:00", "feat: next quarter")
+
+	info, err := getRepoInfo(context.Background(), repoDir, repoOptions{since: "2026-07-01", until: "2026-09-30"})
+	if err != nil {
+		t.Fatalf("getRepoInfo returned error: %v", err)
+	}
+	if info.totalCommits != 2 {
+		t.Fatalf("expected 2 commits in window, got %d", info.totalCommits)
+	}
+	if len(info.contributors) != 1 || info.contributors[0].commits != 2 {
+		t.Fatalf("expected contributor counts scoped to window, got %+v", info.contributors)
+	}
+	if len(info.highlights) != 2 || info.highlights[0].description != "last day of the quarter" {
+		t.Fatalf("expected highlights scoped to window, got %v", info.highlights)
+	}
+	if info.window != "Q3 2026" {
+		t.Fatalf("expected window label Q3 2026, got %q", info.window)
+	}
+}
+
+func TestGetRepoInfo_PathScoped(t *testing.T) {
+	repoDir := setupTestRepo(t)
+	for _, f := range []string{"services/api/main.go", "services/web/app.js"} {
+		path := filepath.Join(repoDir, f)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatalf("mkdir: %v", err)
+		}
+		if err := os.WriteFile(path, []byte(f+"\n"), 0o644); err != nil {
+			t.Fatalf("write file: %v", err)
+		}
+		runInDir(t, repoDir, "git", "add", f)
+		commitAs(t, repoDir, "Owner "+filepath.Base(filepath.Dir(f)), filepath.Base(filepath.Dir(f))+"@example.com", "feat: add "+f)
+	}
+
+	info, err := getRepoInfo(context.Background(), repoDir, repoOptions{paths: []string{"services/api"}})
+	if err != nil {
+		t.Fatalf("getRepoInfo returned error: %v", err)
+	}
+	if info.name != "api" {
+		t.Fatalf("expected title from subdirectory, got %q", info.name)
+	}
+	if info.totalCommits != 1 || len(info.contributors) != 1 || info.contributors[0].name != "Owner api" {
+		t.Fatalf("expected history scoped to services/api, got %d commits, %+v", info.totalCommits, info.contributors)
+	}
+	if len(info.highlights) != 1 || info.highlights[0].description != "add services/api/main.go" {
+		t.Fatalf("expected highlights scoped to services/api, got %v", info.highlights)
+	}
+}
+
+func TestGetRepoInfo_Subdirectory(t *testing.T) {
+	repoDir := setupTestRepo(t)
+	sub := filepath.Join(repoDir, "docs", "guide")
+	if err := os.MkdirAll(sub, 0o755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(repoDir, ".git", "description"), []byte("A test project\n"), 0o644); err != nil {
+		t.Fatalf("write description: %v", err)
+	}
+
+	info, err := getRepoInfo(context.Background(), sub, repoOptions{})
+	if err != nil {
+		t.Fatalf("getRepoInfo returned error: %v", err)
+	}
+	if info.name != filepath.Base(repoDir) {
+		t.Fatalf("expected name of repository root %q, got %q", filepath.Base(repoDir), info.name)
+	}
+	if info.description != "A test project" {
+		t.Fatalf("expected description from the git dir, got %q", info.description)
+	}
+}
+
+func TestGetRepoInfo_LinkedWorktree(t *testing.T) {
+	repoDir := setupTestRepo(t)
+	worktree := filepath.Join(t.TempDir(), "feature-branch")
+	runInDir(t, repoDir, "git", "worktree", "add", "-q", "-b", "feature", worktree)
+
+	info, err := getRepoInfo(context.Background(), worktree, repoOptions{})
+	if err != nil {
+		t.Fatalf("getRepoInfo returned error: %v", err)
+	}
+	if info.name != filepath.Base(repoDir) {
+		t.Fatalf("expected worktree named after main repository %q, got %q", filepath.Base(repoDir), info.name)
+	}
+
+	runInDir(t, repoDir, "git", "remote", "add", "origin", "git@github.com:acme/rocket.git")
+	if info, _ = getRepoInfo(context.Background(), worktree, repoOptions{offline: true}); info.name != "rocket" {
+		t.Fatalf("expected worktree named after origin remote, got %q", info.name)
+	}
+}
+
+func TestGetRepoInfo_BareRepository(t *testing.T) {
+	repoDir := setupTestRepo(t)
+	bare := filepath.Join(t.TempDir(), "widget.git")
+	runInDir(t, repoDir, "git", "clone", "-q", "--bare", repoDir, bare)
+	runInDir(t, bare, "git", "remote", "remove", "origin")
+
+	info, err := getRepoInfo(context.Background(), bare, repoOptions{})
+	if err != nil {
+		t.Fatalf("getRepoInfo returned error: %v", err)
+	}
+	if info.name != "widget" || info.totalCommits != 1 {
+		t.Fatalf("unexpected bare repository info: name %q, %d commits", info.name, info.totalCommits)
+	}
+}
+
+func TestGetRepoInfo_NotARepository(t *testing.T) {
+	_, err := getRepoInfo(context.Background(), t.TempDir(), repoOptions{})
+	if err == nil || !strings.Contains(err.Error(), "not a git repository") {
+		t.Fatalf("expected not a git repository error, got %v", err)
+	}
+}
+
+func TestGetRepoInfo_GitMissing(t *testing.T) {
+	repoDir := setupTestRepo(t)
+	t.Setenv("PATH", t.TempDir())
+
+	_, err := getRepoInfo(context.Background(), repoDir, repoOptions{})
+	if !errors.Is(err, exec.ErrNotFound) {
+		t.Fatalf("expected the missing git binary to be reported, got %v", err)
+	}
+}
+
+func TestParseRemoteURL(t *testing.T) {
+	tests := []struct {
+		raw, host, path string
+	}{
+		{"git@github.com:acme/rocket.git", "github.com", "acme/rocket"},
+		{"https://gitlab.example.com/group/sub/tool.git", "gitlab.example.com", "group/sub/tool"},
+		{"ssh://git@gitea.local:2222/team/app/", "gitea.local", "team/app"},
+		{"/srv/git/project.git", "", "srv/git/project"},
+	}
+	for _, tt := range tests {
+		r, ok := parseRemoteURL(tt.raw)
+		if !ok || r.host != tt.host || r.path != tt.path {
+			t.Errorf("parseRemoteURL(%q) = %+v, %v; want host %q path %q", tt.raw, r, ok, tt.host, tt.path)
+		}
+	}
+}
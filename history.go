@@ -0,0 +1,253 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"os/exec"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// historyFormat is the record layout of the single git log pass. Each
+// commit starts with RS (0x1e), its header fields (parents, author date,
+// name, email, subject, trailers, body) are NUL-separated, trailers
+// are US-separated (0x1f), and GS (0x1d) closes the header so that the
+// --numstat lines which follow can be told apart from a multi-line body.
+const historyFormat = "%x1e%P%x00%at%x00%aN%x00%aE%x00%s%x00%(trailers:only,unfold,separator=%x1f)%x00%b%x1d"
+
+// maxHighlightScan is how many recent non-merge commits are searched for
+// notable scenes, and maxHighlights how many are kept.
+const (
+	maxHighlightScan = 50
+	maxHighlights    = 8
+)
+
+// trailerCredit is one person named in a trailer; role indexes
+// repoOptions.roles, or is -1 for Co-authored-by.
+type trailerCredit struct {
+	role  int
+	ident string
+}
+
+// historyCollector computes every history-derived statistic while git log
+// streams by, so memory grows with the number of people and files rather
+// than with the number of commits.
+type historyCollector struct {
+	opts repoOptions
+
+	totalCommits int
+	authors      *identityResolver
+	credits      map[trailerCredit]int
+	highlights   []highlight
+	scanned      int // non-merge commits considered for highlights
+	timeline     timeline
+
+	// author of the commit whose numstat lines are being read
+	current    authorKey
+	hasCurrent bool
+}
+
+func newHistoryCollector(opts repoOptions) *historyCollector {
+	return &historyCollector{
+		opts:    opts,
+		authors: newIdentityResolver(),
+		credits: make(map[trailerCredit]int),
+	}
+}
+
+// collectHistory runs the single git log pass over the selected history.
+func collectHistory(ctx context.Context, dir string, opts repoOptions) (*historyCollector, error) {
+	h := newHistoryCollector(opts)
+	args := append([]string{"log", "--numstat", "--format=" + historyFormat}, opts.revisionArgs()...)
+	if err := streamCommand(ctx, dir, h.parse, "git", args...); err != nil {
+		return nil, err
+	}
+	return h, nil
+}
+
+// parse consumes git log output in historyFormat.
+func (h *historyCollector) parse(r io.Reader) error {
+	br := bufio.NewReaderSize(r, 64*1024)
+	var header strings.Builder
+	inHeader := false
+	for {
+		line, err := br.ReadString('\n')
+		if strings.HasPrefix(line, "\x1e") {
+			header.Reset()
+			inHeader = true
+			line = line[1:]
+		}
+		if inHeader {
+			if end := strings.IndexByte(line, '\x1d'); end >= 0 {
+				header.WriteString(line[:end])
+				h.addCommit(header.String())
+				inHeader = false
+			} else {
+				header.WriteString(line)
+			}
+		} else if line != "" {
+			h.addChange(strings.TrimSuffix(line, "\n"))
+		}
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// addCommit records one commit header: parents, author date, author,
+// subject, trailers and body.
+func (h *historyCollector) addCommit(header string) {
+	fields := strings.SplitN(header, "\x00", 7)
+	if len(fields) < 7 {
+		h.hasCurrent = false
+		return
+	}
+	parents, date, name, email, subject, trailers, body := fields[0], fields[1], fields[2], fields[3], fields[4], fields[5], fields[6]
+
+	h.totalCommits++
+	var when time.Time
+	if sec, err := strconv.ParseInt(date, 10, 64); err == nil {
+		when = time.Unix(sec, 0)
+		h.timeline.addDate(when)
+	}
+	if strings.Contains(strings.TrimSpace(parents), " ") {
+		// merges count toward the total but credit nobody
+		h.hasCurrent = false
+		return
+	}
+
+	h.current = authorKey{name: name, email: email}
+	h.hasCurrent = true
+	h.authors.add(name, email, 1)
+	h.authors.addCareer(name, email, when, subject)
+
+	for _, trailer := range strings.Split(trailers, "\x1f") {
+		key, value, ok := strings.Cut(trailer, ":")
+		value = strings.TrimSpace(value)
+		if !ok || value == "" {
+			continue
+		}
+		key = strings.TrimSpace(key)
+		if strings.EqualFold(key, "Co-authored-by") {
+			h.credits[trailerCredit{role: -1, ident: value}]++
+			continue
+		}
+		for i, role := range h.opts.roles {
+			if strings.EqualFold(key, role.trailer) {
+				h.credits[trailerCredit{role: i, ident: value}]++
+			}
+		}
+	}
+
+	if h.scanned < maxHighlightScan && len(h.highlights) < maxHighlights {
+		h.scanned++
+		if hl, ok := parseConventionalCommit(subject, body); ok && wantsHighlight(hl, h.opts.types) {
+			h.highlights = append(h.highlights, hl)
+		}
+	}
+}
+
+// addChange records a numstat line, "added\tdeleted\tpath", for the
+// current commit's author.
+func (h *historyCollector) addChange(line string) {
+	if !h.hasCurrent {
+		return
+	}
+	fields := strings.SplitN(line, "\t", 3)
+	if len(fields) != 3 {
+		return
+	}
+	added, _ := strconv.Atoi(fields[0]) // "-" for binary files
+	deleted, _ := strconv.Atoi(fields[1])
+	h.authors.addChange(h.current.name, h.current.email, added, deleted, fields[2])
+}
+
+// resolveTrailers credits trailer idents to co-authors and roles after
+// passing them through .mailmap in one batch.
+func (h *historyCollector) resolveTrailers(ctx context.Context, dir string) []*identityResolver {
+	roles := make([]*identityResolver, len(h.opts.roles))
+	for i := range roles {
+		roles[i] = newIdentityResolver()
+	}
+
+	credits := make([]trailerCredit, 0, len(h.credits))
+	idents := make([]string, 0, len(h.credits))
+	for c := range h.credits {
+		credits = append(credits, c)
+	}
+	// map order is random; keep alias tie-breaks reproducible
+	sort.Slice(credits, func(i, j int) bool {
+		if credits[i].role != credits[j].role {
+			return credits[i].role < credits[j].role
+		}
+		return credits[i].ident < credits[j].ident
+	})
+	for _, c := range credits {
+		idents = append(idents, c.ident)
+	}
+	for i, ident := range checkMailmap(ctx, dir, idents) {
+		n := h.credits[credits[i]]
+		if role := credits[i].role; role >= 0 {
+			roles[role].add(ident.name, ident.email, n)
+		} else {
+			h.authors.addCoAuthor(ident.name, ident.email, n)
+		}
+	}
+	return roles
+}
+
+// streamLines runs a command and hands each line of its stdout, without the
+// newline, to each as it is produced.
+func streamLines(ctx context.Context, dir string, each func(line string), name string, args ...string) error {
+	return streamCommand(ctx, dir, func(r io.Reader) error {
+		br := bufio.NewReaderSize(r, 64*1024)
+		for {
+			line, err := br.ReadString('\n')
+			if line = strings.TrimSuffix(line, "\n"); line != "" {
+				each(line)
+			}
+			if err == io.EOF {
+				return nil
+			}
+			if err != nil {
+				return err
+			}
+		}
+	}, name, args...)
+}
+
+// streamCommand runs a command and hands its stdout to consume as it is
+// produced instead of buffering it. Like runCommand, a failure carries the
+// first line of stderr.
+func streamCommand(ctx context.Context, dir string, consume func(io.Reader) error, name string, args ...string) error {
+	cmd := exec.CommandContext(ctx, name, args...)
+	cmd.Dir = dir
+	var stderr strings.Builder
+	cmd.Stderr = &stderr
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return err
+	}
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+	consumeErr := consume(stdout)
+	if consumeErr != nil {
+		// drain so git is not left blocked on a full pipe
+		io.Copy(io.Discard, stdout)
+	}
+	if err := cmd.Wait(); err != nil {
+		if msg, _, _ := strings.Cut(strings.TrimSpace(stderr.String()), "\n"); msg != "" {
+			return fmt.Errorf("%s %s: %w: %s", name, args[0], err, msg)
+		}
+		return fmt.Errorf("%s %s: %w", name, args[0], err)
+	}
+	return consumeErr
+}
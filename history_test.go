@@ -0,0 +1,133 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// historyRecord renders one commit the way git log prints historyFormat
+// with --numstat.
+func historyRecord(parents, name, email, subject, trailers, body string, numstat ...string) string {
+	return historyRecordAt(1700000000, parents, name, email, subject, trailers, body, numstat...)
+}
+
+func historyRecordAt(when int64, parents, name, email, subject, trailers, body string, numstat ...string) string {
+	var sb strings.Builder
+	sb.WriteString("\x1e" + parents + "\x00" + strconv.FormatInt(when, 10) + "\x00" + name + "\x00" + email + "\x00" + subject + "\x00" + trailers + "\x00" + body + "\x1d\n")
+	if len(numstat) > 0 {
+		sb.WriteString("\n")
+		for _, n := range numstat {
+			sb.WriteString(n + "\n")
+		}
+	}
+	return sb.String()
+}
+
+func TestHistoryCollector_Parse(t *testing.T) {
+	stream := historyRecord("c2 c3", "Merger", "merge@example.com", "Merge branch 'topic'", "", "") +
+		historyRecord("c1", "Alice", "alice@example.com", "feat(api): tokens", "Co-authored-by: Bob <bob@example.com>",
+			"Multi-line\nbody text\n\nCo-authored-by: Bob <bob@example.com>\n", "10\t2\tapi/token.go", "-\t-\tlogo.png") +
+		historyRecord("c0", "Alice", "alice@example.com", "fix: crash", "", "BREAKING CHANGE: config moved\n", "1\t1\tapi/token.go") +
+		historyRecord("", "Bob", "bob@example.com", "initial import", "", "")
+
+	h := newHistoryCollector(repoOptions{})
+	if err := h.parse(strings.NewReader(stream)); err != nil {
+		t.Fatalf("parse returned error: %v", err)
+	}
+	if h.totalCommits != 4 {
+		t.Fatalf("expected merges to count toward the total, got %d", h.totalCommits)
+	}
+	people := h.authors.contributors()
+	if len(people) != 2 || people[0].name != "Alice" {
+		t.Fatalf("merge authors should not be credited, got %+v", people)
+	}
+	alice := people[0]
+	if alice.authored != 2 || alice.added != 11 || alice.deleted != 3 || alice.files != 2 {
+		t.Fatalf("unexpected stats for Alice: %+v", alice)
+	}
+	if h.credits[trailerCredit{role: -1, ident: "Bob <bob@example.com>"}] != 1 {
+		t.Fatalf("expected one co-author credit for Bob, got %v", h.credits)
+	}
+	if len(h.highlights) != 2 || h.highlights[0].scope != "api" || !h.highlights[1].breaking {
+		t.Fatalf("unexpected highlights: %+v", h.highlights)
+	}
+}
+
+func TestGetRepoInfo_MergesCounted(t *testing.T) {
+	repoDir := setupTestRepo(t)
+	runInDir(t, repoDir, "git", "checkout", "-q", "-b", "topic")
+	commitAs(t, repoDir, "Topic Dev", "topic@example.com", "feat: topic work")
+	runInDir(t, repoDir, "git", "checkout", "-q", "-")
+	commitAs(t, repoDir, "Test User", "test@example.com", "fix: mainline work")
+	runInDir(t, repoDir, "git", "merge", "-q", "--no-ff", "-m", "Merge branch 'topic'", "topic")
+
+	info, err := getRepoInfo(context.Background(), repoDir, repoOptions{})
+	if err != nil {
+		t.Fatalf("getRepoInfo returned error: %v", err)
+	}
+	if info.totalCommits != 4 {
+		t.Fatalf("expected 4 commits including the merge, got %d", info.totalCommits)
+	}
+	if info.contributors[0].name != "Test User" || info.contributors[0].commits != 2 {
+		t.Fatalf("merge commit should not be credited, got %+v", info.contributors)
+	}
+}
+
+// syntheticHistory generates n commits by a rotating cast without holding
+// the whole stream in memory.
+type syntheticHistory struct {
+	n, i int
+	buf  strings.Reader
+}
+
+func (s *syntheticHistory) Read(p []byte) (int, error) {
+	for s.buf.Len() == 0 {
+		if s.i >= s.n {
+			return 0, io.EOF
+		}
+		author := s.i % 250
+		record := historyRecord(
+			fmt.Sprintf("%040x", s.i+1),
+			fmt.Sprintf("Dev %d", author),
+			fmt.Sprintf("dev%d@example.com", author),
+			fmt.Sprintf("feat(mod%d): change %d", s.i%20, s.i),
+			"Reviewed-by: Lead <lead@example.com>",
+			"Body line one\nBody line two\n",
+			fmt.Sprintf("%d\t%d\tpkg/mod%d/file%d.go", s.i%40, s.i%7, s.i%20, s.i%500),
+			"3\t1\tREADME.md",
+		)
+		s.buf.Reset(record)
+		s.i++
+	}
+	return s.buf.Read(p)
+}
+
+func BenchmarkHistoryCollector_Parse(b *testing.B) {
+	const commits = 100000
+	opts := repoOptions{roles: defaultTrailerRoles()}
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		h := newHistoryCollector(opts)
+		if err := h.parse(&syntheticHistory{n: commits}); err != nil {
+			b.Fatal(err)
+		}
+		if h.totalCommits != commits {
+			b.Fatalf("parsed %d commits, want %d", h.totalCommits, commits)
+		}
+	}
+}
+
+func TestStreamCommand_ReportsStderr(t *testing.T) {
+	dir := setupTestRepo(t)
+	err := streamCommand(context.Background(), dir, func(r io.Reader) error {
+		_, err := io.Copy(io.Discard, r)
+		return err
+	}, "git", "log", "no-such-revision")
+	if err == nil || !strings.Contains(err.Error(), "no-such-revision") {
+		t.Fatalf("expected git's complaint in the error, got %v", err)
+	}
+}
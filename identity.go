@@ -0,0 +1,302 @@
+package main
+
+import (
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// identityResolver groups the name/email pairs git reports into people.
+// Names and emails already have .mailmap applied by git (%aN / %aE); on top
+// of that, any two spellings that share an email or a normalized name are
+// treated as the same person.
+type identityResolver struct {
+	keys  []authorKey
+	index map[authorKey]int
+	stats []authorStats
+}
+
+type authorKey struct {
+	name  string
+	email string
+}
+
+// authorStats accumulates everything credited to one name/email spelling.
+type authorStats struct {
+	authored   int
+	coAuthored int
+	added      int
+	deleted    int
+	files      map[string]bool
+	fileCount  int            // files counted elsewhere, e.g. in another repository
+	repos      map[string]int // commits per repository when aggregating
+	newcomer   bool           // a merged-in contributor was new to its range
+	veteran    bool           // a merged-in contributor was not
+	first      time.Time      // earliest authored commit
+	last       time.Time      // latest authored commit
+	debut      string         // subject of the earliest authored commit
+}
+
+// addCareer extends a span of authored commits with one dated commit. An
+// earlier or equally old commit replaces the debut, since git log lists
+// history newest first.
+func (s *authorStats) addCareer(when time.Time, subject string) {
+	if when.IsZero() {
+		return
+	}
+	if s.first.IsZero() || !when.After(s.first) {
+		s.first = when
+		s.debut = subject
+	}
+	if when.After(s.last) {
+		s.last = when
+	}
+}
+
+func newIdentityResolver() *identityResolver {
+	return &identityResolver{index: make(map[authorKey]int)}
+}
+
+// add records n authored commits for the given author spelling.
+func (r *identityResolver) add(name, email string, n int) {
+	if s := r.lookup(name, email); s != nil {
+		s.authored += n
+	}
+}
+
+// addCareer records the date and subject of an authored commit.
+func (r *identityResolver) addCareer(name, email string, when time.Time, subject string) {
+	if s := r.lookup(name, email); s != nil {
+		s.addCareer(when, subject)
+	}
+}
+
+// addCoAuthor records n commits credited through a Co-authored-by trailer.
+func (r *identityResolver) addCoAuthor(name, email string, n int) {
+	if s := r.lookup(name, email); s != nil {
+		s.coAuthored += n
+	}
+}
+
+// addChange records one file's numstat line for an authored commit.
+func (r *identityResolver) addChange(name, email string, added, deleted int, path string) {
+	s := r.lookup(name, email)
+	if s == nil {
+		return
+	}
+	s.added += added
+	s.deleted += deleted
+	if s.files == nil {
+		s.files = make(map[string]bool)
+	}
+	s.files[path] = true
+}
+
+// addContributor folds a contributor already resolved in one repository
+// into the resolver, remembering which repository the work came from.
+func (r *identityResolver) addContributor(c contributor, repo string) {
+	emails := c.emails
+	if len(emails) == 0 {
+		emails = []string{""}
+	}
+	s := r.lookup(c.name, emails[0])
+	if s == nil {
+		return
+	}
+	s.authored += c.commits - c.coAuthored
+	s.coAuthored += c.coAuthored
+	s.added += c.added
+	s.deleted += c.deleted
+	s.fileCount += c.files
+	if s.repos == nil {
+		s.repos = make(map[string]int)
+	}
+	s.repos[repo] += c.commits
+	if c.newcomer {
+		s.newcomer = true
+	} else {
+		s.veteran = true
+	}
+	s.addCareer(c.first, c.debut)
+	if c.last.After(s.last) {
+		s.last = c.last
+	}
+	for _, e := range emails[1:] {
+		// zero-count spellings still link the aliases together
+		r.lookup(c.name, e)
+	}
+}
+
+func (r *identityResolver) lookup(name, email string) *authorStats {
+	key := authorKey{name: strings.TrimSpace(name), email: strings.TrimSpace(email)}
+	if key.name == "" && key.email == "" {
+		return nil
+	}
+	i, ok := r.index[key]
+	if !ok {
+		i = len(r.keys)
+		r.index[key] = i
+		r.keys = append(r.keys, key)
+		r.stats = append(r.stats, authorStats{})
+	}
+	return &r.stats[i]
+}
+
+// contributors merges every recorded spelling into one contributor per
+// person, sorted by commit count.
+func (r *identityResolver) contributors() []contributor {
+	parent := make([]int, len(r.keys))
+	for i := range parent {
+		parent[i] = i
+	}
+	var find func(int) int
+	find = func(i int) int {
+		if parent[i] != i {
+			parent[i] = find(parent[i])
+		}
+		return parent[i]
+	}
+	union := func(a, b int) {
+		ra, rb := find(a), find(b)
+		if ra != rb {
+			if rb < ra {
+				ra, rb = rb, ra
+			}
+			parent[rb] = ra
+		}
+	}
+
+	byEmail := make(map[string]int)
+	byName := make(map[string]int)
+	for i, k := range r.keys {
+		if e := normalizeEmail(k.email); e != "" {
+			if j, ok := byEmail[e]; ok {
+				union(i, j)
+			} else {
+				byEmail[e] = i
+			}
+		}
+		if n := normalizeName(k.name); n != "" {
+			if j, ok := byName[n]; ok {
+				union(i, j)
+			} else {
+				byName[n] = i
+			}
+		}
+	}
+
+	groups := make(map[int][]int)
+	var roots []int
+	for i := range r.keys {
+		root := find(i)
+		if _, ok := groups[root]; !ok {
+			roots = append(roots, root)
+		}
+		groups[root] = append(groups[root], i)
+	}
+
+	var result []contributor
+	for _, root := range roots {
+		members := groups[root]
+		c := contributor{}
+		best := -1
+		seen := make(map[string]bool)
+		files := make(map[string]bool)
+		repos := make(map[string]int)
+		newcomer, veteran := false, false
+		var career authorStats
+		for _, i := range members {
+			k := r.keys[i]
+			st := r.stats[i]
+			career.addCareer(st.first, st.debut)
+			if st.last.After(career.last) {
+				career.last = st.last
+			}
+			c.authored += st.authored
+			c.coAuthored += st.coAuthored
+			c.added += st.added
+			c.deleted += st.deleted
+			c.files += st.fileCount
+			for f := range st.files {
+				files[f] = true
+			}
+			for repo, n := range st.repos {
+				repos[repo] += n
+			}
+			newcomer = newcomer || st.newcomer
+			veteran = veteran || st.veteran
+			if k.name != "" && (best < 0 || st.total() > r.stats[best].total()) {
+				best = i
+			}
+			if e := normalizeEmail(k.email); e != "" && !seen[e] {
+				seen[e] = true
+				c.emails = append(c.emails, strings.TrimSpace(k.email))
+			}
+		}
+		if best >= 0 {
+			c.name = norm.NFC.String(r.keys[best].name)
+		} else if len(c.emails) > 0 {
+			c.name = c.emails[0]
+		}
+		sort.Strings(c.emails)
+		c.commits = c.authored + c.coAuthored
+		c.files += len(files)
+		c.newcomer = newcomer && !veteran
+		c.first, c.last, c.debut = career.first, career.last, career.debut
+		for repo, n := range repos {
+			c.repos = append(c.repos, repoShare{repo: repo, commits: n})
+		}
+		sort.Slice(c.repos, func(a, b int) bool {
+			if c.repos[a].commits != c.repos[b].commits {
+				return c.repos[a].commits > c.repos[b].commits
+			}
+			return c.repos[a].repo < c.repos[b].repo
+		})
+		result = append(result, c)
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].commits > result[j].commits
+	})
+	return result
+}
+
+func (s authorStats) total() int {
+	return s.authored + s.coAuthored
+}
+
+// parseIdent splits a "Name <email>" trailer value.
+func parseIdent(s string) (name, email string) {
+	s = strings.TrimSpace(s)
+	open := strings.LastIndexByte(s, '<')
+	if open < 0 || !strings.HasSuffix(s, ">") {
+		return s, ""
+	}
+	return strings.TrimSpace(s[:open]), strings.TrimSpace(s[open+1 : len(s)-1])
+}
+
+// normalizeName folds case and Unicode normalization differences so that
+// "José", "JOSÉ" and "josé" compare equal.
+func normalizeName(name string) string {
+	name = norm.NFKC.String(strings.TrimSpace(name))
+	name = strings.Join(strings.Fields(name), " ")
+	return strings.Map(unicode.ToLower, name)
+}
+
+// normalizeEmail lowercases an address and maps GitHub noreply addresses
+// ("12345+octocat@users.noreply.github.com") to their stable login form.
+func normalizeEmail(email string) string {
+	email = strings.ToLower(strings.TrimSpace(email))
+	const noreply = "@users.noreply.github.com"
+	if strings.HasSuffix(email, noreply) {
+		local := strings.TrimSuffix(email, noreply)
+		if i := strings.IndexByte(local, '+'); i >= 0 {
+			local = local[i+1:]
+		}
+		email = local + noreply
+	}
+	return email
+}
@@ -0,0 +1,164 @@
+package main
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestIdentityResolver_MergesByEmail(t *testing.T) {
+	r := newIdentityResolver()
+	r.add("Alice Smith", "alice@example.com", 5)
+	r.add("asmith", "Alice@Example.com", 2)
+	r.add("Bob", "bob@example.com", 3)
+
+	got := r.contributors()
+	if len(got) != 2 {
+		t.Fatalf("expected 2 contributors, got %d: %+v", len(got), got)
+	}
+	if got[0].name != "Alice Smith" || got[0].commits != 7 {
+		t.Fatalf("expected Alice Smith with 7 commits, got %+v", got[0])
+	}
+	if len(got[0].emails) != 1 {
+		t.Fatalf("expected case-folded emails to collapse, got %v", got[0].emails)
+	}
+}
+
+func TestIdentityResolver_MergesByNormalizedName(t *testing.T) {
+	r := newIdentityResolver()
+	r.add("José", "jose@work.example", 4)
+	r.add("José", "jose@home.example", 1)
+	r.add("JOSÉ", "jose@laptop.example", 1)
+
+	got := r.contributors()
+	if len(got) != 1 {
+		t.Fatalf("expected 1 contributor, got %d: %+v", len(got), got)
+	}
+	if got[0].name != "José" || got[0].commits != 6 || len(got[0].emails) != 3 {
+		t.Fatalf("unexpected merge result: %+v", got[0])
+	}
+}
+
+func TestIdentityResolver_TransitiveAliases(t *testing.T) {
+	r := newIdentityResolver()
+	r.add("Carol", "carol@a.example", 1)
+	r.add("Carol", "carol@b.example", 1)
+	r.add("cdev", "carol@b.example", 1)
+
+	got := r.contributors()
+	if len(got) != 1 || got[0].commits != 3 {
+		t.Fatalf("expected aliases to merge transitively, got %+v", got)
+	}
+}
+
+func TestNormalizeEmail_GitHubNoreply(t *testing.T) {
+	a := normalizeEmail("12345+octocat@users.noreply.github.com")
+	b := normalizeEmail("octocat@users.noreply.github.com")
+	if a != b {
+		t.Fatalf("expected noreply forms to match, got %q and %q", a, b)
+	}
+}
+
+func TestGetRepoInfo_Mailmap(t *testing.T) {
+	repoDir := setupTestRepo(t)
+	commitAs(t, repoDir, "T. User", "old@example.com", "second")
+	mailmap := "Test User <test@example.com> <old@example.com>\n"
+	if err := os.WriteFile(filepath.Join(repoDir, ".mailmap"), []byte(mailmap), 0o644); err != nil {
+		t.Fatalf("write mailmap: %v", err)
+	}
+
+	info, err := getRepoInfo(context.Background(), repoDir, repoOptions{})
+	if err != nil {
+		t.Fatalf("getRepoInfo returned error: %v", err)
+	}
+	if len(info.contributors) != 1 {
+		t.Fatalf("expected mailmap to merge authors, got %+v", info.contributors)
+	}
+	if info.contributors[0].name != "Test User" || info.contributors[0].commits != 2 {
+		t.Fatalf("unexpected contributor: %+v", info.contributors[0])
+	}
+}
+
+func TestParseIdent(t *testing.T) {
+	name, email := parseIdent(" Dana Lee <dana@example.com> ")
+	if name != "Dana Lee" || email != "dana@example.com" {
+		t.Fatalf("unexpected ident: %q %q", name, email)
+	}
+}
+
+func TestGetRepoInfo_CoAuthors(t *testing.T) {
+	repoDir := setupTestRepo(t)
+	commitAs(t, repoDir, "Test User", "test@example.com",
+		"feat: pair work\n\nCo-authored-by: Pat Pair <pat@example.com>")
+	commitAs(t, repoDir, "Test User", "test@example.com",
+		"fix: more pairing\n\nCo-authored-by: Pat Pair <pat@example.com>")
+
+	info, err := getRepoInfo(context.Background(), repoDir, repoOptions{})
+	if err != nil {
+		t.Fatalf("getRepoInfo returned error: %v", err)
+	}
+	if len(info.contributors) != 2 {
+		t.Fatalf("expected author and co-author, got %+v", info.contributors)
+	}
+	pat := info.contributors[1]
+	if pat.name != "Pat Pair" || pat.coAuthored != 2 || pat.authored != 0 || pat.commits != 2 {
+		t.Fatalf("unexpected co-author: %+v", pat)
+	}
+}
+
+func TestIdentityResolver_Career(t *testing.T) {
+	r := newIdentityResolver()
+	day := func(d int) time.Time { return time.Date(2021, 1, d, 0, 0, 0, 0, time.UTC) }
+	// newest first, as git log lists them
+	r.addCareer("Alice", "alice@work.com", day(20), "feat: latest work")
+	r.addCareer("alice", "alice@home.com", day(5), "fix typo in README")
+	r.addCareer("Alice", "alice@work.com", day(10), "feat: middle")
+
+	people := r.contributors()
+	if len(people) != 1 {
+		t.Fatalf("expected aliases merged, got %+v", people)
+	}
+	a := people[0]
+	if !a.first.Equal(day(5)) || !a.last.Equal(day(20)) || a.debut != "fix typo in README" {
+		t.Fatalf("unexpected career: %v – %v, debut %q", a.first, a.last, a.debut)
+	}
+
+	merged := newIdentityResolver()
+	merged.addContributor(contributor{name: "Alice", emails: []string{"alice@work.com"}, first: day(8), last: day(30), debut: "feat: other repo"}, "web")
+	merged.addContributor(a, "api")
+	if m := merged.contributors()[0]; m.debut != "fix typo in README" || !m.last.Equal(day(30)) {
+		t.Fatalf("unexpected merged career: %q, %v", m.debut, m.last)
+	}
+}
+
+func TestGetRepoInfo_Career(t *testing.T) {
+	repoDir := setupTestRepo(t)
+	commitWithEnv(t, repoDir, "docs: fix typo in README",
+		"GIT_AUTHOR_NAME=Newbie", "GIT_AUTHOR_EMAIL=new@example.com",
+		"GIT_AUTHOR_DATE=2021-06-01T12:00:00", "GIT_COMMITTER_DATE=2021-06-01T12:00:00")
+	commitWithEnv(t, repoDir, "feat: grown up",
+		"GIT_AUTHOR_NAME=Newbie", "GIT_AUTHOR_EMAIL=new@example.com",
+		"GIT_AUTHOR_DATE=2024-06-01T12:00:00", "GIT_COMMITTER_DATE=2024-06-01T12:00:00")
+
+	// a range or window that starts later still shows the real debut
+	for _, opts := range []repoOptions{{}, {rev: "HEAD~1..HEAD"}, {since: "2024-01-01"}} {
+		info, err := getRepoInfo(context.Background(), repoDir, opts)
+		if err != nil {
+			t.Fatalf("getRepoInfo returned error: %v", err)
+		}
+		found := false
+		for _, c := range info.contributors {
+			if c.name == "Newbie" {
+				found = true
+				if got := careerLine(c); got != "since 2021 · debut: 'fix typo in README'" {
+					t.Errorf("%+v: unexpected career line %q", opts, got)
+				}
+			}
+		}
+		if !found {
+			t.Errorf("%+v: Newbie not credited: %+v", opts, info.contributors)
+		}
+	}
+}
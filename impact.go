@@ -0,0 +1,70 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+)
+
+// rankModes lists the accepted --rank-by values.
+var rankModes = []string{"commits", "lines", "files", "score"}
+
+func validRankMode(mode string) bool {
+	for _, m := range rankModes {
+		if m == mode {
+			return true
+		}
+	}
+	return false
+}
+
+// impactScore weighs a contributor's work so that neither a flood of tiny
+// commits nor one giant vendored import dominates: one commit counts as much
+// as two files touched or a hundred lines changed.
+func impactScore(c contributor) float64 {
+	return float64(c.commits) + float64(c.files)*0.5 + float64(c.added+c.deleted)*0.01
+}
+
+// rankContributors orders contributors by the chosen measure. The first
+// contributor becomes the project lead. Ties keep commit order.
+func rankContributors(contributors []contributor, mode string) {
+	var key func(c contributor) float64
+	switch mode {
+	case "lines":
+		key = func(c contributor) float64 { return float64(c.added + c.deleted) }
+	case "files":
+		key = func(c contributor) float64 { return float64(c.files) }
+	case "score":
+		key = impactScore
+	default:
+		key = func(c contributor) float64 { return float64(c.commits) }
+	}
+	sort.SliceStable(contributors, func(i, j int) bool {
+		return key(contributors[i]) > key(contributors[j])
+	})
+}
+
+// impactSummary renders line statistics like "+12,304 / −8,911", or ""
+// when no line data was collected.
+func impactSummary(c contributor) string {
+	if c.added == 0 && c.deleted == 0 {
+		return ""
+	}
+	return fmt.Sprintf("+%s / −%s", formatCount(c.added), formatCount(c.deleted))
+}
+
+// formatCount adds thousands separators: 12304 becomes "12,304".
+func formatCount(n int) string {
+	s := strconv.Itoa(n)
+	neg := n < 0
+	if neg {
+		s = s[1:]
+	}
+	for i := len(s) - 3; i > 0; i -= 3 {
+		s = s[:i] + "," + s[i:]
+	}
+	if neg {
+		s = "-" + s
+	}
+	return s
+}
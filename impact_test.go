@@ -0,0 +1,66 @@
+package main
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestFormatCount(t *testing.T) {
+	tests := map[int]string{0: "0", 999: "999", 1000: "1,000", 12304: "12,304", 1234567: "1,234,567"}
+	for n, want := range tests {
+		if got := formatCount(n); got != want {
+			t.Errorf("formatCount(%d) = %q, want %q", n, got, want)
+		}
+	}
+}
+
+func TestRankContributors(t *testing.T) {
+	people := []contributor{
+		{name: "Tiny", commits: 50, added: 50, deleted: 10, files: 3},
+		{name: "Big", commits: 5, added: 12000, deleted: 3000, files: 40},
+	}
+
+	rankContributors(people, "commits")
+	if people[0].name != "Tiny" {
+		t.Fatalf("commits ranking should put Tiny first, got %s", people[0].name)
+	}
+	rankContributors(people, "lines")
+	if people[0].name != "Big" {
+		t.Fatalf("lines ranking should put Big first, got %s", people[0].name)
+	}
+	rankContributors(people, "score")
+	if people[0].name != "Big" {
+		t.Fatalf("score ranking should put Big first, got %s", people[0].name)
+	}
+}
+
+func TestGetRepoInfo_Numstat(t *testing.T) {
+	repoDir := setupTestRepo(t)
+	body := strings.Repeat("line\n", 10)
+	if err := os.WriteFile(filepath.Join(repoDir, "main.go"), []byte(body), 0o644); err != nil {
+		t.Fatalf("write file: %v", err)
+	}
+	runInDir(t, repoDir, "git", "add", "main.go")
+	commitAs(t, repoDir, "Test User", "test@example.com", "feat: add main")
+	if err := os.WriteFile(filepath.Join(repoDir, "main.go"), []byte("line\n"), 0o644); err != nil {
+		t.Fatalf("write file: %v", err)
+	}
+	runInDir(t, repoDir, "git", "add", "main.go")
+	commitAs(t, repoDir, "Test User", "test@example.com", "refactor: shrink main")
+
+	info, err := getRepoInfo(context.Background(), repoDir, repoOptions{})
+	if err != nil {
+		t.Fatalf("getRepoInfo returned error: %v", err)
+	}
+	c := info.contributors[0]
+	// README.md (+1), main.go (+10), main.go (-9)
+	if c.added != 11 || c.deleted != 9 || c.files != 2 {
+		t.Fatalf("unexpected numstat totals: +%d -%d files %d", c.added, c.deleted, c.files)
+	}
+	if got := impactSummary(c); got != "+11 / −9" {
+		t.Fatalf("impactSummary = %q", got)
+	}
+}
@@ -0,0 +1,221 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// languageShare is one language's part of the tracked source bytes.
+type languageShare struct {
+	name    string
+	bytes   int64
+	percent float64
+}
+
+// languageExtensions maps lowercase file extensions to languages. Prose and
+// data formats (Markdown, JSON, YAML…) are left out so they never outweigh
+// the code.
+var languageExtensions = map[string]string{
+	".go":     "Go",
+	".rs":     "Rust",
+	".c":      "C",
+	".h":      "C",
+	".cc":     "C++",
+	".cpp":    "C++",
+	".cxx":    "C++",
+	".hh":     "C++",
+	".hpp":    "C++",
+	".cs":     "C#",
+	".java":   "Java",
+	".kt":     "Kotlin",
+	".kts":    "Kotlin",
+	".scala":  "Scala",
+	".swift":  "Swift",
+	".m":      "Objective-C",
+	".mm":     "Objective-C++",
+	".py":     "Python",
+	".pyi":    "Python",
+	".rb":     "Ruby",
+	".php":    "PHP",
+	".pl":     "Perl",
+	".pm":     "Perl",
+	".lua":    "Lua",
+	".js":     "JavaScript",
+	".mjs":    "JavaScript",
+	".cjs":    "JavaScript",
+	".jsx":    "JavaScript",
+	".ts":     "TypeScript",
+	".tsx":    "TypeScript",
+	".vue":    "Vue",
+	".svelte": "Svelte",
+	".html":   "HTML",
+	".htm":    "HTML",
+	".css":    "CSS",
+	".scss":   "SCSS",
+	".sass":   "Sass",
+	".less":   "Less",
+	".sh":     "Shell",
+	".bash":   "Shell",
+	".zsh":    "Shell",
+	".fish":   "Shell",
+	".ps1":    "PowerShell",
+	".ex":     "Elixir",
+	".exs":    "Elixir",
+	".erl":    "Erlang",
+	".hs":     "Haskell",
+	".ml":     "OCaml",
+	".mli":    "OCaml",
+	".fs":     "F#",
+	".clj":    "Clojure",
+	".cljs":   "Clojure",
+	".elm":    "Elm",
+	".dart":   "Dart",
+	".zig":    "Zig",
+	".nim":    "Nim",
+	".jl":     "Julia",
+	".r":      "R",
+	".sql":    "SQL",
+	".proto":  "Protocol Buffer",
+	".tf":     "HCL",
+	".hcl":    "HCL",
+	".nix":    "Nix",
+	".vim":    "Vim Script",
+	".el":     "Emacs Lisp",
+	".asm":    "Assembly",
+	".s":      "Assembly",
+	".tex":    "TeX",
+}
+
+// languageFilenames maps extensionless file names to languages.
+var languageFilenames = map[string]string{
+	"makefile":       "Makefile",
+	"gnumakefile":    "Makefile",
+	"dockerfile":     "Dockerfile",
+	"rakefile":       "Ruby",
+	"gemfile":        "Ruby",
+	"cmakelists.txt": "CMake",
+}
+
+// vendoredDirs are path segments whose contents were not written by the
+// project.
+var vendoredDirs = []string{
+	"vendor", "node_modules", "third_party", "third-party", "thirdparty",
+	"bower_components", "dist", ".yarn", "Pods", "Carthage",
+}
+
+// generatedSuffixes mark files produced by tools rather than people.
+var generatedSuffixes = []string{
+	".min.js", ".min.css", ".pb.go", ".pb.gw.go", "_pb2.py", ".pb.cc", ".pb.h",
+	"_generated.go", ".generated.go", ".g.dart", ".designer.cs",
+}
+
+// fileLanguage returns the language of a tracked path, or "" when it is
+// not code or is vendored or generated.
+func fileLanguage(p string) string {
+	for _, seg := range strings.Split(path.Dir(p), "/") {
+		if containsString(vendoredDirs, seg) {
+			return ""
+		}
+	}
+	base := path.Base(p)
+	lower := strings.ToLower(base)
+	if strings.HasPrefix(lower, "zz_generated") {
+		return ""
+	}
+	for _, s := range generatedSuffixes {
+		if strings.HasSuffix(lower, s) {
+			return ""
+		}
+	}
+	if lang, ok := languageFilenames[lower]; ok {
+		return lang
+	}
+	if strings.HasPrefix(lower, "dockerfile.") {
+		return "Dockerfile"
+	}
+	return languageExtensions[strings.ToLower(path.Ext(base))]
+}
+
+// detectLanguages weighs every file in rev's tree by size and returns the
+// languages from largest to smallest. Sizes come from the tree itself, so
+// this works in bare repositories. Like the history, paths are relative to
+// dir and the whole tree is scanned without them.
+func detectLanguages(ctx context.Context, dir, rev string, paths []string) ([]languageShare, error) {
+	args := []string{"ls-tree", "-r", "-l", "-z"}
+	if len(paths) == 0 {
+		args = append(args, "--full-tree")
+	}
+	args = append(append(args, rev), paths...)
+	out, err := runCommand(ctx, dir, "git", args...)
+	if err != nil {
+		return nil, err
+	}
+
+	bytes := make(map[string]int64)
+	for _, entry := range strings.Split(string(out), "\x00") {
+		// "<mode> <type> <object> <size>\t<path>"
+		meta, name, ok := strings.Cut(entry, "\t")
+		fields := strings.Fields(meta)
+		if !ok || len(fields) != 4 || fields[1] != "blob" {
+			continue
+		}
+		size, err := strconv.ParseInt(fields[3], 10, 64)
+		if err != nil {
+			continue
+		}
+		if lang := fileLanguage(name); lang != "" {
+			bytes[lang] += size
+		}
+	}
+	return languageShares(bytes), nil
+}
+
+// languageShares turns byte totals into shares sorted largest first.
+func languageShares(bytes map[string]int64) []languageShare {
+	var total int64
+	for _, n := range bytes {
+		total += n
+	}
+	if total == 0 {
+		return nil
+	}
+	shares := make([]languageShare, 0, len(bytes))
+	for name, n := range bytes {
+		shares = append(shares, languageShare{name: name, bytes: n, percent: float64(n) * 100 / float64(total)})
+	}
+	sort.Slice(shares, func(i, j int) bool {
+		if shares[i].bytes != shares[j].bytes {
+			return shares[i].bytes > shares[j].bytes
+		}
+		return shares[i].name < shares[j].name
+	})
+	return shares
+}
+
+// maxSummaryLanguages is how many languages languageSummary names.
+const maxSummaryLanguages = 3
+
+// languageSummary renders the breakdown for the stats section, e.g.
+// "72% Go, 20% Shell, 8% Makefile". Languages under 1% are left out, a
+// project in one language is just named, and it falls back to the forge's
+// language when there is no breakdown.
+func languageSummary(info repoInfo) string {
+	var parts []string
+	for _, s := range info.languages {
+		if len(parts) == maxSummaryLanguages || s.percent < 1 {
+			break
+		}
+		parts = append(parts, fmt.Sprintf("%.0f%% %s", s.percent, s.name))
+	}
+	switch len(parts) {
+	case 0:
+		return info.language
+	case 1:
+		return info.languages[0].name
+	}
+	return strings.Join(parts, ", ")
+}
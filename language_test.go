@@ -0,0 +1,103 @@
+package main
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestFileLanguage(t *testing.T) {
+	tests := map[string]string{
+		"main.go":                           "Go",
+		"cmd/tool/Main.GO":                  "Go",
+		"scripts/install.sh":                "Shell",
+		"Makefile":                          "Makefile",
+		"deploy/Dockerfile.prod":            "Dockerfile",
+		"web/src/App.tsx":                   "TypeScript",
+		"README.md":                         "",
+		"config.yaml":                       "",
+		"vendor/github.com/x/y/y.go":        "",
+		"web/node_modules/a/index.js":       "",
+		"web/dist/bundle.js":                "",
+		"static/app.min.js":                 "",
+		"api/v1/service.pb.go":              "",
+		"pkg/apis/zz_generated.deepcopy.go": "",
+	}
+	for p, want := range tests {
+		if got := fileLanguage(p); got != want {
+			t.Errorf("fileLanguage(%q) = %q, want %q", p, got, want)
+		}
+	}
+}
+
+func TestLanguageSummary(t *testing.T) {
+	info := repoInfo{languages: languageShares(map[string]int64{
+		"Go": 7200, "Shell": 2000, "Makefile": 500, "Dockerfile": 250, "Awk": 50,
+	})}
+	if got := languageSummary(info); got != "72% Go, 20% Shell, 5% Makefile" {
+		t.Errorf("got %q", got)
+	}
+
+	info = repoInfo{languages: languageShares(map[string]int64{"Go": 100})}
+	if got := languageSummary(info); got != "Go" {
+		t.Errorf("single language: got %q", got)
+	}
+
+	if got := languageSummary(repoInfo{language: "Rust"}); got != "Rust" {
+		t.Errorf("forge fallback: got %q", got)
+	}
+}
+
+func TestGetRepoInfo_Languages(t *testing.T) {
+	repoDir := setupTestRepo(t)
+	files := map[string]int{
+		"main.go":                 3000,
+		"cmd/tool/tool.go":        1000,
+		"scripts/release.sh":      1000,
+		"vendor/big/big.go":       50000,
+		"docs/guide.md":           20000,
+		"internal/x_generated.go": 9000,
+	}
+	for f, size := range files {
+		path := filepath.Join(repoDir, f)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatalf("mkdir: %v", err)
+		}
+		if err := os.WriteFile(path, []byte(strings.Repeat("x", size)), 0o644); err != nil {
+			t.Fatalf("write file: %v", err)
+		}
+	}
+	runInDir(t, repoDir, "git", "add", ".")
+	runInDir(t, repoDir, "git", "commit", "-m", "feat: add code")
+
+	info, err := getRepoInfo(context.Background(), repoDir, repoOptions{})
+	if err != nil {
+		t.Fatalf("getRepoInfo returned error: %v", err)
+	}
+	if info.language != "Go" || languageSummary(info) != "80% Go, 20% Shell" {
+		t.Fatalf("unexpected languages: %q, %+v", info.language, info.languages)
+	}
+
+	// from a subdirectory the whole tree still counts, unless --path narrows it
+	sub := filepath.Join(repoDir, "scripts")
+	if info, _ = getRepoInfo(context.Background(), sub, repoOptions{}); languageSummary(info) != "80% Go, 20% Shell" {
+		t.Fatalf("subdirectory: got %+v", info.languages)
+	}
+	if info, _ = getRepoInfo(context.Background(), repoDir, repoOptions{paths: []string{"scripts"}}); languageSummary(info) != "Shell" {
+		t.Fatalf("path scoped: got %+v", info.languages)
+	}
+
+	// a range is measured at its upper end, together with --path
+	runInDir(t, repoDir, "git", "tag", "v1.0.0")
+	if err := os.WriteFile(filepath.Join(repoDir, "scripts", "build.py"), []byte(strings.Repeat("x", 9000)), 0o644); err != nil {
+		t.Fatalf("write file: %v", err)
+	}
+	runInDir(t, repoDir, "git", "add", ".")
+	runInDir(t, repoDir, "git", "commit", "-m", "feat: add build script")
+	opts := repoOptions{rev: "HEAD~2..v1.0.0", paths: []string{"scripts"}}
+	if info, _ = getRepoInfo(context.Background(), repoDir, opts); languageSummary(info) != "Shell" {
+		t.Fatalf("range and path scoped: got %+v", info.languages)
+	}
+}
@@ -0,0 +1,198 @@
+package main
+
+import (
+	"context"
+	"embed"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode"
+)
+
+// licenseTexts holds the terms of common licenses, one file per SPDX
+// identifier. GPL-family texts stop at "END OF TERMS AND CONDITIONS" since
+// projects often leave out the how-to-apply appendix.
+//
+//go:embed licenses/*.txt
+var licenseTexts embed.FS
+
+// licenseMatchThreshold is the share of a license's word pairs that must
+// appear in a file for it to count as that license. It leaves room for
+// copyright lines, reflowed paragraphs and small edits.
+const licenseMatchThreshold = 0.9
+
+// maxLicenseFileSize skips files too large to be a license.
+const maxLicenseFileSize = 256 * 1024
+
+// licenseSupplements maps licenses that are shipped alongside the text they
+// extend. LGPL-3.0 projects carry the GPL-3.0 text too, and are not
+// dual-licensed because of it.
+var licenseSupplements = map[string]string{
+	"LGPL-3.0": "GPL-3.0",
+}
+
+type licenseTemplate struct {
+	id    string
+	pairs map[string]bool
+}
+
+var (
+	licenseTemplatesOnce sync.Once
+	licenseTemplates     []licenseTemplate
+)
+
+func loadLicenseTemplates() []licenseTemplate {
+	licenseTemplatesOnce.Do(func() {
+		files, _ := licenseTexts.ReadDir("licenses")
+		for _, f := range files {
+			text, err := licenseTexts.ReadFile("licenses/" + f.Name())
+			if err != nil {
+				continue
+			}
+			licenseTemplates = append(licenseTemplates, licenseTemplate{
+				id:    strings.TrimSuffix(f.Name(), ".txt"),
+				pairs: wordPairs(string(text)),
+			})
+		}
+	})
+	return licenseTemplates
+}
+
+// classifyLicense returns the SPDX identifiers of every known license whose
+// text appears in text. A license wholly contained in another match, like
+// BSD-2-Clause inside BSD-3-Clause, is dropped in favour of the larger one.
+func classifyLicense(text string) []string {
+	doc := wordPairs(text)
+	if len(doc) == 0 {
+		return nil
+	}
+	var matches []licenseTemplate
+	for _, t := range loadLicenseTemplates() {
+		if coverage(t.pairs, doc) >= licenseMatchThreshold {
+			matches = append(matches, t)
+		}
+	}
+
+	var ids []string
+	for _, m := range matches {
+		subsumed := false
+		for _, other := range matches {
+			if other.id != m.id && len(other.pairs) > len(m.pairs) && coverage(m.pairs, other.pairs) >= licenseMatchThreshold {
+				subsumed = true
+				break
+			}
+		}
+		if !subsumed {
+			ids = append(ids, m.id)
+		}
+	}
+	return ids
+}
+
+// coverage is the share of want's word pairs present in have.
+func coverage(want, have map[string]bool) float64 {
+	if len(want) == 0 {
+		return 0
+	}
+	found := 0
+	for p := range want {
+		if have[p] {
+			found++
+		}
+	}
+	return float64(found) / float64(len(want))
+}
+
+// wordPairs reduces a license to its set of adjacent lowercase word pairs,
+// ignoring punctuation, layout, the licence/license spelling and copyright
+// lines, which differ between every copy.
+func wordPairs(text string) map[string]bool {
+	var words []string
+	for _, line := range strings.Split(text, "\n") {
+		trimmed := strings.TrimLeft(strings.TrimSpace(line), "#*-> ")
+		lower := strings.ToLower(trimmed)
+		if strings.HasPrefix(lower, "copyright") || strings.HasPrefix(lower, "(c)") || strings.HasPrefix(lower, "©") {
+			continue
+		}
+		for _, w := range strings.FieldsFunc(lower, func(r rune) bool {
+			return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+		}) {
+			if w == "licence" {
+				w = "license"
+			}
+			words = append(words, w)
+		}
+	}
+	pairs := make(map[string]bool, len(words))
+	for i := 1; i < len(words); i++ {
+		pairs[words[i-1]+" "+words[i]] = true
+	}
+	return pairs
+}
+
+// isLicenseFile reports whether a root file name looks like a license:
+// LICENSE, LICENCE, COPYING or UNLICENSE with any suffix, such as
+// "LICENSE.md", "LICENSE-APACHE" or "COPYING.LESSER".
+func isLicenseFile(name string) bool {
+	upper := strings.ToUpper(name)
+	for _, prefix := range []string{"LICENSE", "LICENCE", "COPYING", "UNLICENSE"} {
+		if strings.HasPrefix(upper, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// detectLicense classifies the license files at the root of rev's tree and
+// returns their SPDX identifiers joined with " OR ", or "" when none is
+// recognized. Reading from git rather than the working tree also covers
+// bare repositories.
+func detectLicense(ctx context.Context, dir, rev string) (string, error) {
+	out, err := runCommand(ctx, dir, "git", "ls-tree", "-l", "--full-tree", rev)
+	if err != nil {
+		return "", err
+	}
+
+	var names []string
+	for _, line := range strings.Split(string(out), "\n") {
+		// "<mode> <type> <object> <size>\t<name>"
+		meta, name, ok := strings.Cut(line, "\t")
+		fields := strings.Fields(meta)
+		if !ok || len(fields) != 4 || fields[1] != "blob" || !isLicenseFile(name) {
+			continue
+		}
+		if size, err := strconv.Atoi(fields[3]); err != nil || size > maxLicenseFileSize {
+			continue
+		}
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var ids []string
+	for _, name := range names {
+		text, err := runCommand(ctx, dir, "git", "cat-file", "blob", rev+":"+name)
+		if err != nil {
+			return "", err
+		}
+		for _, id := range classifyLicense(string(text)) {
+			if !containsString(ids, id) {
+				ids = append(ids, id)
+			}
+		}
+	}
+
+	var kept []string
+	for _, id := range ids {
+		supplemented := false
+		for extension, base := range licenseSupplements {
+			if id == base && containsString(ids, extension) {
+				supplemented = true
+			}
+		}
+		if !supplemented {
+			kept = append(kept, id)
+		}
+	}
+	return strings.Join(kept, " OR "), nil
+}
@@ -0,0 +1,145 @@
+package main
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestClassifyLicense_EmbeddedTexts(t *testing.T) {
+	files, err := licenseTexts.ReadDir("licenses")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, f := range files {
+		id := strings.TrimSuffix(f.Name(), ".txt")
+		text, _ := licenseTexts.ReadFile("licenses/" + f.Name())
+		withHolder := "Copyright (c) 2026 Jane Doe\n\n" + string(text)
+		if got := strings.Join(classifyLicense(withHolder), " OR "); got != id {
+			t.Errorf("classifyLicense(%s) = %q", id, got)
+		}
+	}
+}
+
+func TestClassifyLicense_Variants(t *testing.T) {
+	project, err := os.ReadFile("LICENSE")
+	if err != nil {
+		t.Fatal(err)
+	}
+	mit, _ := licenseTexts.ReadFile("licenses/MIT.txt")
+	apache, _ := licenseTexts.ReadFile("licenses/Apache-2.0.txt")
+	reflowed := "The MIT Licence (MIT)\n\n" + strings.Join(strings.Fields(string(mit)), " ")
+
+	tests := []struct {
+		name string
+		text string
+		want string
+	}{
+		{"project LICENSE", string(project), "MIT"},
+		{"reflowed markdown", reflowed, "MIT"},
+		{"dual license in one file", string(apache) + "\n---\n" + string(mit), "Apache-2.0 OR MIT"},
+		{"not a license", "# rocket\n\nA fast rocket. See the docs for more.\n", ""},
+	}
+	for _, tt := range tests {
+		if got := strings.Join(classifyLicense(tt.text), " OR "); got != tt.want {
+			t.Errorf("%s: got %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestGetRepoInfo_LicenseFiles(t *testing.T) {
+	repoDir := setupTestRepo(t)
+	for name, text := range map[string]string{
+		"LICENSE-MIT":    "licenses/MIT.txt",
+		"LICENSE-APACHE": "licenses/Apache-2.0.txt",
+	} {
+		data, _ := licenseTexts.ReadFile(text)
+		if err := os.WriteFile(filepath.Join(repoDir, name), data, 0o644); err != nil {
+			t.Fatalf("write file: %v", err)
+		}
+	}
+	runInDir(t, repoDir, "git", "add", ".")
+	runInDir(t, repoDir, "git", "commit", "-m", "chore: add licenses")
+
+	info, err := getRepoInfo(context.Background(), repoDir, repoOptions{})
+	if err != nil {
+		t.Fatalf("getRepoInfo returned error: %v", err)
+	}
+	if info.license != "Apache-2.0 OR MIT" {
+		t.Fatalf("expected dual license, got %q", info.license)
+	}
+}
+
+func TestGetRepoInfo_LicenseAtRange(t *testing.T) {
+	repoDir := setupTestRepo(t)
+	for _, step := range []struct{ text, tag string }{
+		{"licenses/MIT.txt", "v1.0.0"},
+		{"licenses/Apache-2.0.txt", "v2.0.0"},
+	} {
+		data, _ := licenseTexts.ReadFile(step.text)
+		if err := os.WriteFile(filepath.Join(repoDir, "LICENSE"), data, 0o644); err != nil {
+			t.Fatalf("write file: %v", err)
+		}
+		runInDir(t, repoDir, "git", "add", ".")
+		runInDir(t, repoDir, "git", "commit", "-m", "chore: license for "+step.tag)
+		runInDir(t, repoDir, "git", "tag", step.tag)
+	}
+
+	info, err := getRepoInfo(context.Background(), repoDir, repoOptions{rev: "HEAD~2..v1.0.0"})
+	if err != nil {
+		t.Fatalf("getRepoInfo returned error: %v", err)
+	}
+	if info.license != "MIT" {
+		t.Fatalf("expected the license as of v1.0.0, got %q", info.license)
+	}
+}
+
+func TestGetRepoInfo_LicenseFromSubdirectory(t *testing.T) {
+	repoDir := setupTestRepo(t)
+	data, _ := licenseTexts.ReadFile("licenses/MIT.txt")
+	if err := os.WriteFile(filepath.Join(repoDir, "LICENSE"), data, 0o644); err != nil {
+		t.Fatalf("write file: %v", err)
+	}
+	subDir := filepath.Join(repoDir, "cmd", "tool")
+	if err := os.MkdirAll(subDir, 0o755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(subDir, "main.go"), []byte("package main\n"), 0o644); err != nil {
+		t.Fatalf("write file: %v", err)
+	}
+	runInDir(t, repoDir, "git", "add", ".")
+	runInDir(t, repoDir, "git", "commit", "-m", "chore: add license and tool")
+
+	info, err := getRepoInfo(context.Background(), subDir, repoOptions{})
+	if err != nil {
+		t.Fatalf("getRepoInfo returned error: %v", err)
+	}
+	if info.license != "MIT" {
+		t.Fatalf("expected the root LICENSE to be found from a subdirectory, got %q", info.license)
+	}
+}
+
+func TestDetectLicense_LGPLWithGPL(t *testing.T) {
+	repoDir := setupTestRepo(t)
+	for name, text := range map[string]string{
+		"COPYING":        "licenses/GPL-3.0.txt",
+		"COPYING.LESSER": "licenses/LGPL-3.0.txt",
+	} {
+		data, _ := licenseTexts.ReadFile(text)
+		if err := os.WriteFile(filepath.Join(repoDir, name), data, 0o644); err != nil {
+			t.Fatalf("write file: %v", err)
+		}
+	}
+	runInDir(t, repoDir, "git", "add", ".")
+	runInDir(t, repoDir, "git", "commit", "-m", "chore: add licenses")
+
+	got, err := detectLicense(context.Background(), repoDir, "HEAD")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != "LGPL-3.0" {
+		t.Fatalf("expected LGPL-3.0, got %q", got)
+	}
+}
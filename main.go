@@ -1,8 +1,13 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"syscall"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"golang.org/x/term"
@@ -14,9 +19,62 @@ var (
 )
 
 type config struct {
-	theme  string
-	output string
-	dir    string
+	theme       string
+	output      string
+	dirs        []string
+	title       string
+	roles       []trailerRole
+	since       string
+	until       string
+	rev         string
+	paths       []string
+	rankBy      string
+	types       []string
+	bots        string
+	botPatterns []string
+	forges      []forgeEndpoint
+	offline     bool
+	font        *font // title font, blockFont unless --font is given
+
+	descriptionOrder []string
+
+	// passthrough holds the data flags to repeat when re-running under VHS
+	passthrough []string
+}
+
+func (c *config) repoOptions() repoOptions {
+	return repoOptions{
+		roles:       mergeTrailerRoles(defaultTrailerRoles(), c.roles),
+		since:       c.since,
+		until:       c.until,
+		rev:         c.rev,
+		paths:       c.paths,
+		rankBy:      c.rankBy,
+		types:       c.types,
+		bots:        c.bots,
+		botPatterns: c.botPatterns,
+		forges:      c.forges,
+		offline:     c.offline,
+
+		descriptionOrder: c.descriptionOrder,
+	}
+}
+
+// gifTarget returns the directory VHS should run in and the arguments to
+// repeat there. A single repository is recorded from inside it; several
+// are passed by absolute path.
+func (c *config) gifTarget() (string, []string) {
+	if len(c.dirs) == 1 {
+		return c.dirs[0], c.passthrough
+	}
+	args := append([]string(nil), c.passthrough...)
+	for _, d := range c.dirs {
+		if abs, err := filepath.Abs(d); err == nil {
+			d = abs
+		}
+		args = append(args, d)
+	}
+	return "", args
 }
 
 func main() {
@@ -30,11 +88,23 @@ func main() {
 		return
 	}
 
-	info, err := getRepoInfo(cfg.dir)
+	// Ctrl+C during collection cancels every running git and gh process;
+	// once the TUI starts, bubbletea handles keys itself.
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	info, err := collectRepoInfo(ctx, cfg.dirs, cfg.repoOptions(), cfg.title)
+	interrupted := ctx.Err() != nil
+	stop()
+	if interrupted {
+		fmt.Fprintln(os.Stderr, "Interrupted")
+		os.Exit(130)
+	}
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 		os.Exit(1)
 	}
+	for _, f := range info.failures {
+		fmt.Fprintf(os.Stderr, "Warning: %s\n", sanitizeText(f.String()))
+	}
 
 	width := 80
 	height := 24
@@ -44,13 +114,14 @@ func main() {
 	}
 
 	if cfg.output != "" {
-		credits := buildCredits(info, 80)
+		credits := buildCredits(info, cfg.font, 80)
 		var cards []matrixCard
 		switch cfg.theme {
 		default:
-			cards = buildMatrixCards(info, 80, 24)
+			cards = buildMatrixCards(info, cfg.font, 80, 24)
 		}
-		if err := generateGIF(cfg.output, cfg.theme, cfg.dir, credits, len(cards)); err != nil {
+		dir, extraArgs := cfg.gifTarget()
+		if err := generateGIF(cfg.output, cfg.theme, dir, extraArgs, credits, len(cards)); err != nil {
 			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 			os.Exit(1)
 		}
@@ -58,45 +129,7 @@ func main() {
 		return
 	}
 
-	var m model
-
-	switch cfg.theme {
-	case "matrix":
-		cards := buildMatrixCards(info, width, height)
-		m = model{
-			height:  height,
-			width:   width,
-			theme:   cfg.theme,
-			cards:   cards,
-			cardIdx: 0,
-			mState:  mvsRain,
-		}
-		m.initRain()
-	case "spiderman":
-		cards := buildSpidermanCards(info, width, height)
-		wf := newWebField(width, height*len(cards))
-		m = model{
-			height:   height,
-			width:    width,
-			theme:    cfg.theme,
-			cards:    cards,
-			cardIdx:  0,
-			mState:   mvsRain,
-			webField: wf,
-		}
-		m.initRain()
-	default:
-		credits := buildCredits(info, width)
-		sf := newStarField(width, len(credits))
-		m = model{
-			lines:     credits,
-			offset:    0,
-			height:    height,
-			width:     width,
-			starField: sf,
-			theme:     cfg.theme,
-		}
-	}
+	m := newModel(info, cfg.theme, cfg.font, width, height)
 
 	p := tea.NewProgram(m, tea.WithAltScreen())
 	if _, err := p.Run(); err != nil {
@@ -107,6 +140,7 @@ func main() {
 
 func parseArgs(args []string) (*config, error) {
 	cfg := &config{theme: "default"}
+	var positional []string
 
 	for i := 0; i < len(args); i++ {
 		arg := args[i]
@@ -129,30 +163,216 @@ func parseArgs(args []string) (*config, error) {
 				return nil, fmt.Errorf("missing value for --output")
 			}
 			cfg.output = args[i]
+		case "--role":
+			i++
+			if i >= len(args) {
+				return nil, fmt.Errorf("missing value for --role")
+			}
+			role, err := parseTrailerRole(args[i])
+			if err != nil {
+				return nil, err
+			}
+			cfg.roles = append(cfg.roles, role)
+			cfg.passthrough = append(cfg.passthrough, arg, args[i])
+		case "--range":
+			i++
+			if i >= len(args) {
+				return nil, fmt.Errorf("missing value for --range")
+			}
+			if cfg.rev != "" {
+				return nil, fmt.Errorf("only one revision range can be provided")
+			}
+			cfg.rev = args[i]
+			cfg.passthrough = append(cfg.passthrough, arg, args[i])
+		case "--path":
+			i++
+			if i >= len(args) {
+				return nil, fmt.Errorf("missing value for --path")
+			}
+			cfg.paths = append(cfg.paths, args[i])
+			cfg.passthrough = append(cfg.passthrough, arg, args[i])
+		case "--rank-by":
+			i++
+			if i >= len(args) {
+				return nil, fmt.Errorf("missing value for --rank-by")
+			}
+			if !validRankMode(args[i]) {
+				return nil, fmt.Errorf("invalid --rank-by %q, expected one of: %s", args[i], strings.Join(rankModes, ", "))
+			}
+			cfg.rankBy = args[i]
+			cfg.passthrough = append(cfg.passthrough, arg, args[i])
+		case "--types":
+			i++
+			if i >= len(args) {
+				return nil, fmt.Errorf("missing value for --types")
+			}
+			cfg.types = parseHighlightTypes(args[i])
+			cfg.passthrough = append(cfg.passthrough, arg, args[i])
+		case "--bots":
+			i++
+			if i >= len(args) {
+				return nil, fmt.Errorf("missing value for --bots")
+			}
+			if !validBotMode(args[i]) {
+				return nil, fmt.Errorf("invalid --bots %q, expected one of: %s", args[i], strings.Join(botModes, ", "))
+			}
+			cfg.bots = args[i]
+			cfg.passthrough = append(cfg.passthrough, arg, args[i])
+		case "--bot-pattern":
+			i++
+			if i >= len(args) {
+				return nil, fmt.Errorf("missing value for --bot-pattern")
+			}
+			if !validBotPattern(args[i]) {
+				return nil, fmt.Errorf("invalid --bot-pattern %q: malformed glob", args[i])
+			}
+			cfg.botPatterns = append(cfg.botPatterns, args[i])
+			cfg.passthrough = append(cfg.passthrough, arg, args[i])
+		case "--forge":
+			i++
+			if i >= len(args) {
+				return nil, fmt.Errorf("missing value for --forge")
+			}
+			forge, err := parseForgeEndpoint(args[i])
+			if err != nil {
+				return nil, err
+			}
+			cfg.forges = append(cfg.forges, forge)
+			cfg.passthrough = append(cfg.passthrough, arg, args[i])
+		case "--description-from":
+			i++
+			if i >= len(args) {
+				return nil, fmt.Errorf("missing value for --description-from")
+			}
+			order, err := parseDescriptionOrder(args[i])
+			if err != nil {
+				return nil, err
+			}
+			cfg.descriptionOrder = order
+			cfg.passthrough = append(cfg.passthrough, arg, args[i])
+		case "--offline":
+			cfg.offline = true
+			cfg.passthrough = append(cfg.passthrough, arg)
+		case "--font":
+			i++
+			if i >= len(args) {
+				return nil, fmt.Errorf("missing value for --font")
+			}
+			f, err := loadFont(args[i])
+			if err != nil {
+				return nil, err
+			}
+			cfg.font = f
+			// VHS records from the repository, so pass font files by
+			// absolute path
+			name := args[i]
+			if !containsString(embeddedFontNames(), strings.TrimSuffix(name, ".flf")) {
+				if abs, err := filepath.Abs(name); err == nil {
+					name = abs
+				}
+			}
+			cfg.passthrough = append(cfg.passthrough, arg, name)
+		case "--manifest":
+			i++
+			if i >= len(args) {
+				return nil, fmt.Errorf("missing value for --manifest")
+			}
+			dirs, err := readManifest(args[i])
+			if err != nil {
+				return nil, err
+			}
+			cfg.dirs = append(cfg.dirs, dirs...)
+		case "--title":
+			i++
+			if i >= len(args) {
+				return nil, fmt.Errorf("missing value for --title")
+			}
+			cfg.title = args[i]
+			cfg.passthrough = append(cfg.passthrough, arg, args[i])
+		case "--since", "--until":
+			i++
+			if i >= len(args) {
+				return nil, fmt.Errorf("missing value for %s", arg)
+			}
+			if arg == "--since" {
+				cfg.since = args[i]
+			} else {
+				cfg.until = args[i]
+			}
+			cfg.passthrough = append(cfg.passthrough, arg, args[i])
 		default:
 			if len(arg) > 0 && arg[0] == '-' {
 				return nil, fmt.Errorf("unknown flag: %s", arg)
 			}
-			if cfg.dir != "" {
-				return nil, fmt.Errorf("only one target directory can be provided")
+			positional = append(positional, arg)
+		}
+	}
+
+	// A positional argument is a range only if git resolves it in the
+	// repository being credited; existing directories always win.
+	repoDir := "."
+	for _, arg := range positional {
+		if isDir(arg) {
+			repoDir = arg
+			break
+		}
+	}
+	for _, arg := range positional {
+		switch {
+		case isDir(arg) || !strings.Contains(arg, ".."):
+			cfg.dirs = append(cfg.dirs, arg)
+		case isRevisionRange(repoDir, arg):
+			if cfg.rev != "" {
+				return nil, fmt.Errorf("only one revision range can be provided")
 			}
-			cfg.dir = arg
+			cfg.rev = arg
+			cfg.passthrough = append(cfg.passthrough, "--range", arg)
+		default:
+			return nil, fmt.Errorf("%q is neither a directory nor a revision range", arg)
 		}
 	}
 
+	if cfg.font == nil {
+		cfg.font = blockFont
+	}
 	return cfg, nil
 }
 
+func isDir(path string) bool {
+	stat, err := os.Stat(path)
+	return err == nil && stat.IsDir()
+}
+
 func printHelp() {
 	fmt.Println("gitcredits - Turn your Git repo into movie-style rolling credits")
 	fmt.Println()
-	fmt.Printf("Usage: gitcredits [options] [directory]\n\n")
+	fmt.Printf("Usage: gitcredits [options] [directory...] [range]\n\n")
 	fmt.Println("Arguments:")
-	fmt.Println("  directory       Target git repository directory (defaults to current directory)")
+	fmt.Println("  directory       Target git repository directory (defaults to current directory);")
+	fmt.Println("                  several directories are merged into one product roll")
+	fmt.Println("  range           Revision range to credit, e.g. v1.2.0..v1.3.0 (same as --range)")
 	fmt.Println()
 	fmt.Println("Options:")
 	fmt.Println("  --theme <name>   Theme: default, matrix, spiderman")
 	fmt.Println("  --output <file>  Export credits as GIF")
+	fmt.Println("  --manifest <file> Merge the repositories listed in a file, one per line")
+	fmt.Println("  --title <name>   Title for the credits, e.g. \"ACME PLATFORM\"")
+	fmt.Println("  --range <revs>   Credit a release, e.g. v1.2.0..v1.3.0")
+	fmt.Println("  --path <path>    Only credit history under a path (repeatable)")
+	fmt.Println("  --since <date>   Only credit commits after a date (2026-07-01, \"3 months ago\")")
+	fmt.Println("  --until <date>   Only credit commits before a date")
+	fmt.Println("  --types <list>   Commit types shown as notable scenes (default feat,fix,perf)")
+	fmt.Println("  --rank-by <by>   Order contributors by commits, lines, files or score")
+	fmt.Println("  --bots <mode>    Bot accounts: separate (default), hide, include")
+	fmt.Println("  --bot-pattern <glob>  Treat matching names/emails as bots (repeatable)")
+	fmt.Println("  --forge <k=URL>  Fetch metadata from a self-hosted forge, e.g. gitlab=https://git.example.com")
+	fmt.Println("                   (kinds: github, gitlab, gitea; repeatable)")
+	fmt.Println("  --description-from <list>  Where to look for the tagline, in order")
+	fmt.Println("                   (default git,forge,readme,package.json,Cargo.toml,pyproject.toml,go.mod)")
+	fmt.Println("  --offline        Don't fetch stars, license or language from the forge")
+	fmt.Printf("  --font <name>    Title font: %s, or a FIGlet .flf file\n", strings.Join(embeddedFontNames(), ", "))
+	fmt.Println("  --role <k=TITLE> Credit a commit trailer as a department, e.g. Acked-by=\"ACKED BY\"")
+	fmt.Println("                   (repeatable; an empty TITLE hides a default role)")
 	fmt.Println("  --version, -v    Show version")
 	fmt.Println("  --help, -h       Show this help")
 }
@@ -34,119 +34,321 @@ func matrixHeroTitle(rank int, commits int) string {
 
 // A "card" is one screen of content to display
 type matrixCard struct {
-	lines []string // text content, indexed by row (len = height)
+	lines []string     // text content, indexed by row (len = height)
+	kinds []creditKind // the role of each row, for styling
 }
 
-func buildMatrixCards(info repoInfo, width, height int) []matrixCard {
-	var cards []matrixCard
+// cardContent is one card before it is fitted to the screen. The header
+// and footer repeat on every page when the body is too tall for one card,
+// and the header's heading is numbered, e.g. "EPIC MOMENTS (2/3)". Pages
+// without a heading end with the number instead.
+type cardContent struct {
+	header []creditLine
+	body   []creditLine
+	footer []creditLine
+}
 
-	center := func(s string) string {
-		return centerText(s, width)
+// paginate lays the content out on as many cards of the given size as
+// its body needs, wrapping lines that are too wide. Pages break at blank
+// lines or before a subheading where one is near, and never inside the
+// rows of a big-font title.
+func (c cardContent) paginate(width, height int) []matrixCard {
+	header, body, footer := wrapLines(c.header, width), wrapLines(c.body, width), wrapLines(c.footer, width)
+	room := height - len(header) - len(footer)
+	if room < 1 {
+		// too short for the frame; give the body the whole card
+		header, footer, room = nil, nil, height
+	}
+	body = trimBlankLines(body)
+	numberLast := !hasHeading(header) && len(body) > room
+	if numberLast {
+		room-- // for the page number
+	}
+	if room < 1 {
+		room = 1
 	}
 
-	makeCard := func(content []string) matrixCard {
-		lines := make([]string, height)
-		startY := (height - len(content)) / 2
-		if startY < 0 {
-			startY = 0
-		}
-		for i, line := range content {
-			if startY+i < height {
-				lines[startY+i] = line
+	var pages [][]creditLine
+	for ; len(body) > 0; body = trimBlankLines(body) {
+		n := pageBreak(body, room)
+		pages = append(pages, trimBlankLines(body[:n]))
+		body = body[n:]
+	}
+	if len(pages) == 0 {
+		pages = [][]creditLine{nil}
+	}
+
+	cards := make([]matrixCard, 0, len(pages))
+	for i, page := range pages {
+		h := header
+		rows := append([]creditLine(nil), page...)
+		if len(pages) > 1 {
+			if numberLast {
+				rows = append(rows, creditLine{kind: kindMeta, text: fmt.Sprintf("(%d/%d)", i+1, len(pages))})
+			} else {
+				h = numberHeading(header, i+1, len(pages))
 			}
 		}
-		return matrixCard{lines: lines}
-	}
-
-	// card 0: title (big + description + stats summary)
-	var titleContent []string
-	titleContent = append(titleContent, center("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━"))
-	titleContent = append(titleContent, "")
-	titleRows := bigText(info.name)
-	for _, row := range titleRows {
-		titleContent = append(titleContent, center(row))
-	}
-	titleContent = append(titleContent, "")
-	if info.description != "" {
-		titleContent = append(titleContent, center("\""+info.description+"\""))
-		titleContent = append(titleContent, "")
-	}
-	if info.language != "" || info.stars > 0 {
-		var meta []string
-		if info.language != "" {
-			meta = append(meta, info.language)
+		rows = append(append(append([]creditLine(nil), h...), rows...), footer...)
+		cards = append(cards, placeCard(rows, width, height))
+	}
+	return cards
+}
+
+// pageBreak returns how many lines of body go on a page with room lines:
+// up to a blank line or subheading in the page's second half, else as many
+// as fit, moved back to keep a title's rows together.
+func pageBreak(body []creditLine, room int) int {
+	if room >= len(body) {
+		return len(body)
+	}
+	inTitle := func(i int) bool {
+		return i > 0 && body[i-1].kind == kindTitle && body[i].kind == kindTitle
+	}
+	for i := room; i > room/2; i-- {
+		if (body[i].kind == kindBlank || body[i].kind == kindSubheading) && !inTitle(i) {
+			return i
+		}
+	}
+	n := room
+	for n > 0 && inTitle(n) {
+		n--
+	}
+	if n == 0 {
+		// the title alone is taller than the card
+		return room
+	}
+	return n
+}
+
+// hasHeading reports whether header has a line numberHeading can number.
+func hasHeading(header []creditLine) bool {
+	for _, l := range header {
+		if l.kind == kindHeading || l.kind == kindSubheading {
+			return true
 		}
-		if info.stars > 0 {
-			meta = append(meta, fmt.Sprintf("★ %d stars", info.stars))
+	}
+	return false
+}
+
+// wrapLines wraps each line to fit within the card margins.
+func wrapLines(lines []creditLine, width int) []creditLine {
+	var out []creditLine
+	for _, l := range lines {
+		for _, part := range wrapText(l.text, width-2*layoutMargin) {
+			out = append(out, creditLine{kind: l.kind, text: part})
 		}
-		if info.license != "" {
-			meta = append(meta, info.license)
+	}
+	return out
+}
+
+// trimBlankLines drops spacers from both ends of lines.
+func trimBlankLines(lines []creditLine) []creditLine {
+	for len(lines) > 0 && lines[0].kind == kindBlank {
+		lines = lines[1:]
+	}
+	for len(lines) > 0 && lines[len(lines)-1].kind == kindBlank {
+		lines = lines[:len(lines)-1]
+	}
+	return lines
+}
+
+// numberHeading returns a copy of header with "(page/pages)" after its
+// first heading.
+func numberHeading(header []creditLine, page, pages int) []creditLine {
+	numbered := append([]creditLine(nil), header...)
+	for i, l := range numbered {
+		if l.kind == kindHeading || l.kind == kindSubheading {
+			numbered[i].text = fmt.Sprintf("%s (%d/%d)", l.text, page, pages)
+			break
 		}
-		titleContent = append(titleContent, center(strings.Join(meta, "  ·  ")))
-	}
-	titleContent = append(titleContent, "")
-	titleContent = append(titleContent, center("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━"))
-	cards = append(cards, makeCard(titleContent))
-
-	// hero cards
-	spacedName := func(name string) string {
-		upper := strings.ToUpper(name)
-		runes := []rune(upper)
-		var spaced []rune
-		for i, r := range runes {
-			spaced = append(spaced, r)
-			if i < len(runes)-1 {
-				if r == ' ' {
-					spaced = append(spaced, ' ', ' ')
-				} else {
-					spaced = append(spaced, ' ')
-				}
+	}
+	return numbered
+}
+
+// placeCard centers rows vertically on a card of the given height and
+// horizontally across width.
+func placeCard(rows []creditLine, width, height int) matrixCard {
+	card := matrixCard{lines: make([]string, height), kinds: make([]creditKind, height)}
+	startY := (height - len(rows)) / 2
+	if startY < 0 {
+		startY = 0
+	}
+	for i, l := range rows {
+		if startY+i < height {
+			if l.text != "" {
+				card.lines[startY+i] = centerText(l.text, width)
 			}
+			card.kinds[startY+i] = l.kind
 		}
-		return string(spaced)
-	}
-
-	for rank, c := range info.contributors {
-		var content []string
-		content = append(content, center("━━━━━━━━━━━━━━━━━━━━━━━━"))
-		content = append(content, "")
-		content = append(content, center(matrixHeroTitle(rank, c.commits)))
-		content = append(content, "")
-		content = append(content, "")
-		content = append(content, center(spacedName(c.name)))
-		content = append(content, "")
-		content = append(content, center(fmt.Sprintf("⚡ %d commits ⚡", c.commits)))
-		content = append(content, "")
-		content = append(content, center("━━━━━━━━━━━━━━━━━━━━━━━━"))
-		cards = append(cards, makeCard(content))
-	}
-
-	// highlights
-	if len(info.highlights) > 0 {
-		var content []string
-		content = append(content, center("E P I C   M O M E N T S"))
-		content = append(content, "")
-		for _, h := range info.highlights {
-			content = append(content, center("⚡ "+h))
+	}
+	return card
+}
+
+// kindAt returns the role of a card row.
+func (c matrixCard) kindAt(row int) creditKind {
+	if row < len(c.kinds) {
+		return c.kinds[row]
+	}
+	return kindBlank
+}
+
+// joinMeta joins a title block's meta lines into one line, leaving out
+// the kinds in skip.
+func joinMeta(b creditBlock, sep string, skip ...creditKind) string {
+	var parts []string
+	for _, l := range b.lines {
+		if !containsKind(skip, l.kind) {
+			parts = append(parts, l.text)
+		}
+	}
+	return strings.Join(parts, sep)
+}
+
+func containsKind(kinds []creditKind, k creditKind) bool {
+	for _, v := range kinds {
+		if v == k {
+			return true
 		}
-		cards = append(cards, makeCard(content))
 	}
+	return false
+}
+
+func buildMatrixCards(info repoInfo, titleFont *font, width, height int) []matrixCard {
+	var cards []matrixCard
+	doc := buildCreditsDoc(info)
 
-	// stats
-	var statsContent []string
-	statsContent = append(statsContent, center(fmt.Sprintf("%d COMMITS  ·  %d HEROES", info.totalCommits, len(info.contributors))))
-	if info.stars > 0 {
-		statsContent = append(statsContent, center(fmt.Sprintf("★ %d STARGAZERS ★", info.stars)))
+	line := func(kind creditKind, s string) creditLine {
+		return creditLine{kind: kind, text: s}
 	}
-	if info.language != "" {
-		statsContent = append(statsContent, center("Forged in "+info.language))
+	blank := creditLine{}
+	add := func(c cardContent) {
+		cards = append(cards, c.paginate(width, height)...)
 	}
-	cards = append(cards, makeCard(statsContent))
+	bordered := func(rule string, header, body []creditLine) cardContent {
+		return cardContent{
+			header: append([]creditLine{line(kindDivider, rule), blank}, header...),
+			body:   body,
+			footer: []creditLine{blank, line(kindDivider, rule)},
+		}
+	}
+	const wideRule = "━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━"
+	const rule = "━━━━━━━━━━━━━━━━━━━━━━━━"
 
-	// will return
-	cards = append(cards, makeCard([]string{
-		center("THE CONTRIBUTORS WILL RETURN"),
-	}))
+	for _, s := range doc.sections {
+		switch s.kind {
+		case sectionTitle:
+			// card 0: title (big + description + stats summary)
+			var content []creditLine
+			content = append(content, titleLines(s.blocks[0], titleFont, width)...)
+			if meta := s.blocks[1]; len(meta.lines) > 0 {
+				content = append(content, line(kindMeta, joinMeta(meta, "  ·  ")))
+			}
+			add(bordered(wideRule, nil, content))
+
+		case sectionLead, sectionCast:
+			// hero cards
+			for _, b := range s.blocks {
+				header := []creditLine{line(kindSubheading, matrixHeroTitle(b.rank, b.person.commits)), blank}
+				if !b.person.newcomer {
+					// keep the names level with newcomers' INTRODUCING row
+					header = append(header, blank)
+				}
+				var content []creditLine
+				for _, l := range b.lines {
+					switch l.kind {
+					case kindIntro:
+						content = append(content, line(l.kind, "✦ INTRODUCING ✦"))
+					case kindName:
+						content = append(content, line(l.kind, spacedCaps(l.text)), blank)
+					case kindSummary:
+						content = append(content, line(l.kind, "⚡ "+l.text+" ⚡"))
+					case kindImpact:
+						content = append(content, line(kindDetail, l.text))
+					default:
+						content = append(content, l)
+					}
+				}
+				add(bordered(rule, header, content))
+			}
+
+		case sectionDepartment, sectionEffects:
+			// department cards from commit trailers, then automation credits
+			header := []creditLine{line(kindHeading, spacedCaps(s.heading)), blank}
+			var content []creditLine
+			for _, b := range s.blocks {
+				content = append(content, line(kindName, roleCreditLine(b.lines[0])))
+			}
+			add(bordered(rule, header, content))
+
+		case sectionScenes:
+			header := []creditLine{line(kindHeading, "E P I C   M O M E N T S"), blank}
+			var content []creditLine
+			for _, b := range s.blocks {
+				for _, l := range b.lines {
+					if l.kind == kindSubheading {
+						content = append(content, line(l.kind, strings.ToUpper(l.text)))
+					} else {
+						content = append(content, line(l.kind, "⚡ "+sceneLine(l)))
+					}
+				}
+			}
+			add(cardContent{header: header, body: content})
+
+		case sectionStats:
+			// the languages come before the timeline, and the license is
+			// left to the title card
+			var counts []string
+			var stars, languages, facts []creditLine
+			for _, b := range s.blocks {
+				for _, l := range b.lines {
+					switch l.kind {
+					case kindStat:
+						counts = append(counts, fmt.Sprintf("%d %s", l.count, matrixStatLabel(l.text)))
+					case kindStar:
+						stars = append(stars, line(l.kind, fmt.Sprintf("★ %d %s ★", l.count, l.text)))
+					case kindLanguages:
+						languages = append(languages, line(kindFact, "Forged in "+l.text))
+					case kindFact:
+						facts = append(facts, l)
+					}
+				}
+			}
+			content := []creditLine{line(kindStat, strings.Join(counts, "  ·  "))}
+			content = append(append(append(content, stars...), languages...), facts...)
+			add(cardContent{body: content})
+
+		case sectionEnd:
+			add(cardContent{body: []creditLine{line(kindEnd, "THE CONTRIBUTORS WILL RETURN")}})
+		}
+	}
 
 	return cards
 }
+
+// titleLines lays out the title block for a card: the big title, then
+// taglines and description, each group followed by a blank line.
+func titleLines(b creditBlock, titleFont *font, width int) []creditLine {
+	var content []creditLine
+	for i, l := range b.lines {
+		if l.kind == kindTitle {
+			for _, row := range fitTitle(l.text, titleFont, width) {
+				content = append(content, creditLine{kind: kindTitle, text: row})
+			}
+		} else {
+			content = append(content, l)
+		}
+		if i+1 == len(b.lines) || b.lines[i+1].kind != l.kind {
+			content = append(content, creditLine{})
+		}
+	}
+	return content
+}
+
+// matrixStatLabel renames the stats for the matrix theme.
+func matrixStatLabel(label string) string {
+	if label == "CONTRIBUTORS" {
+		return "HEROES"
+	}
+	return label
+}
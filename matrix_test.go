@@ -1,6 +1,7 @@
 package main
 
 import (
+	"fmt"
 	"strings"
 	"testing"
 )
@@ -13,7 +14,7 @@ func TestBuildMatrixCards_TitleCard(t *testing.T) {
 			{name: "Alice", commits: 50},
 		},
 	}
-	cards := buildMatrixCards(info, 80, 24)
+	cards := buildMatrixCards(info, blockFont, 80, 24)
 
 	if len(cards) == 0 {
 		t.Fatal("buildMatrixCards returned no cards")
@@ -41,7 +42,7 @@ func TestBuildMatrixCards_HeroCards(t *testing.T) {
 			{name: "Bob", commits: 10},
 		},
 	}
-	cards := buildMatrixCards(info, 80, 24)
+	cards := buildMatrixCards(info, blockFont, 80, 24)
 
 	// should have: title + 2 hero cards + stats + will return = 5 minimum
 	if len(cards) < 4 {
@@ -57,7 +58,7 @@ func TestBuildMatrixCards_WillReturn(t *testing.T) {
 			{name: "Alice", commits: 1},
 		},
 	}
-	cards := buildMatrixCards(info, 80, 24)
+	cards := buildMatrixCards(info, blockFont, 80, 24)
 
 	lastCard := cards[len(cards)-1]
 	found := false
@@ -72,6 +73,28 @@ func TestBuildMatrixCards_WillReturn(t *testing.T) {
 	}
 }
 
+func TestBuildMatrixCards_StatsWording(t *testing.T) {
+	info := repoInfo{
+		name:         "test",
+		totalCommits: 3,
+		license:      "MIT",
+		languages:    []languageShare{{name: "Go", bytes: 10, percent: 100}},
+		contributors: []contributor{{name: "Alice", commits: 3}},
+	}
+	cards := buildMatrixCards(info, blockFont, 80, 24)
+
+	var stats []string
+	for _, l := range cards[len(cards)-2].lines {
+		if s := strings.TrimSpace(l); s != "" {
+			stats = append(stats, s)
+		}
+	}
+	want := "3 COMMITS  ·  1 HEROES|Forged in Go"
+	if got := strings.Join(stats, "|"); got != want {
+		t.Errorf("stats card = %q, want %q", got, want)
+	}
+}
+
 func TestMatrixHeroTitle(t *testing.T) {
 	tests := []struct {
 		rank    int
@@ -99,7 +122,7 @@ func TestBuildMatrixCards_CardHeight(t *testing.T) {
 			{name: "Alice", commits: 1},
 		},
 	}
-	cards := buildMatrixCards(info, 80, 24)
+	cards := buildMatrixCards(info, blockFont, 80, 24)
 
 	for i, card := range cards {
 		if len(card.lines) != 24 {
@@ -107,3 +130,184 @@ func TestBuildMatrixCards_CardHeight(t *testing.T) {
 		}
 	}
 }
+
+func TestBuildCards_PaginateScenes(t *testing.T) {
+	info := repoInfo{
+		name:         "test",
+		totalCommits: 30,
+		contributors: []contributor{{name: "Alice", commits: 30}},
+	}
+	for i := 0; i < 30; i++ {
+		kind := "feat"
+		if i%3 == 0 {
+			kind = "fix"
+		}
+		info.highlights = append(info.highlights, highlight{kind: kind, description: fmt.Sprintf("scene number %d", i)})
+	}
+
+	tests := []struct {
+		theme   string
+		build   func(repoInfo, *font, int, int) []matrixCard
+		heading string
+		divided bool
+	}{
+		{"matrix", buildMatrixCards, "E P I C   M O M E N T S", false},
+		{"spiderman", buildSpidermanCards, "N O T A B L E   C O M M I T S", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.theme, func(t *testing.T) {
+			cards := tt.build(info, blockFont, 80, 24)
+			var pages []matrixCard
+			for _, card := range cards {
+				if len(card.lines) != 24 {
+					t.Fatalf("card has %d lines, want 24", len(card.lines))
+				}
+				for _, l := range card.lines {
+					if strings.Contains(l, tt.heading) {
+						pages = append(pages, card)
+						break
+					}
+				}
+			}
+			if len(pages) < 2 {
+				t.Fatalf("got %d scene cards, want the 30 scenes split across several", len(pages))
+			}
+
+			seen := map[string]int{}
+			for i, card := range pages {
+				label := fmt.Sprintf("%s (%d/%d)", tt.heading, i+1, len(pages))
+				text := strings.Join(card.lines, "\n")
+				if !strings.Contains(text, label) {
+					t.Errorf("scene card %d is missing %q:\n%s", i+1, label, text)
+				}
+				dividers := 0
+				for r, l := range card.lines {
+					if card.kindAt(r) == kindDivider {
+						dividers++
+					}
+					if j := strings.Index(l, "scene number "); j >= 0 {
+						seen[strings.TrimSpace(l[j:])]++
+					}
+				}
+				if tt.divided && dividers != 2 {
+					t.Errorf("scene card %d has %d dividers, want 2", i+1, dividers)
+				}
+			}
+			for i := 0; i < 30; i++ {
+				if n := seen[fmt.Sprintf("scene number %d", i)]; n != 1 {
+					t.Errorf("scene %d shown %d times, want once", i, n)
+				}
+			}
+		})
+	}
+}
+
+func TestModelResize_Repaginates(t *testing.T) {
+	info := repoInfo{
+		name:         "test",
+		totalCommits: 20,
+		contributors: []contributor{{name: "Alice", commits: 20}},
+	}
+	for i := 0; i < 20; i++ {
+		info.highlights = append(info.highlights, highlight{kind: "feat", description: fmt.Sprintf("scene number %d", i)})
+	}
+
+	for _, theme := range []string{"matrix", "spiderman"} {
+		t.Run(theme, func(t *testing.T) {
+			m := newModel(info, theme, blockFont, 80, 60)
+			m.cardIdx = len(m.cards) - 1
+			before := len(m.cards)
+
+			m.resize(60, 16)
+			if len(m.cards) <= before {
+				t.Errorf("got %d cards after shrinking to 16 rows, want more than %d", len(m.cards), before)
+			}
+			if m.cardIdx >= len(m.cards) {
+				t.Errorf("cardIdx %d out of range for %d cards", m.cardIdx, len(m.cards))
+			}
+			if len(m.resolveMap) != 16 || len(m.resolveMap[0]) != 60 {
+				t.Errorf("resolve grid is %dx%d, want 16x60", len(m.resolveMap), len(m.resolveMap[0]))
+			}
+			for i, card := range m.cards {
+				if len(card.lines) != 16 {
+					t.Errorf("card %d has %d lines, want 16", i, len(card.lines))
+				}
+				for _, l := range card.lines {
+					if w := displayWidth(l); w > 60 {
+						t.Errorf("card %d line %q is %d cells wide, want at most 60", i, l, w)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestBuildCards_PaginateTitleCard(t *testing.T) {
+	info := repoInfo{
+		name:         "rocket",
+		description:  strings.Repeat("A very long description that wraps over many lines. ", 8),
+		language:     "Go",
+		license:      "MIT",
+		totalCommits: 1,
+		contributors: []contributor{{name: "Alice", commits: 1}},
+	}
+	want := len(fitTitle(info.name, blockFont, 40))
+	for _, build := range []func(repoInfo, *font, int, int) []matrixCard{buildMatrixCards, buildSpidermanCards} {
+		cards := build(info, blockFont, 40, 16)
+		var pages []matrixCard
+		for _, card := range cards {
+			for r := range card.lines {
+				if card.kindAt(r) == kindTitle || card.kindAt(r) == kindQuote {
+					pages = append(pages, card)
+					break
+				}
+			}
+		}
+		if len(pages) < 2 {
+			t.Fatalf("expected the title card to overflow 16 rows, got %d pages", len(pages))
+		}
+		titleRows := 0
+		for i, card := range pages {
+			rows := 0
+			for r := range card.lines {
+				if card.kindAt(r) == kindTitle {
+					rows++
+				}
+			}
+			if rows != 0 && rows != want {
+				t.Errorf("page %d has %d of the title's %d rows", i+1, rows, want)
+			}
+			titleRows += rows
+			if label := fmt.Sprintf("(%d/%d)", i+1, len(pages)); !strings.Contains(strings.Join(card.lines, "\n"), label) {
+				t.Errorf("page %d is missing %q", i+1, label)
+			}
+		}
+		if titleRows != want {
+			t.Errorf("title drawn with %d rows, want %d", titleRows, want)
+		}
+	}
+}
+
+func TestPaginate_KeepsTitleRowsTogether(t *testing.T) {
+	body := []creditLine{{kind: kindQuote, text: "before"}, {}}
+	for i := 0; i < 5; i++ {
+		body = append(body, creditLine{kind: kindTitle, text: "█████"})
+	}
+	body = append(body, creditLine{}, creditLine{kind: kindQuote, text: "after"})
+
+	cards := cardContent{body: body}.paginate(20, 6)
+	if len(cards) < 2 {
+		t.Fatalf("expected several pages, got %d", len(cards))
+	}
+	for i, card := range cards {
+		rows := 0
+		for r := range card.lines {
+			if card.kindAt(r) == kindTitle {
+				rows++
+			}
+		}
+		if rows != 0 && rows != 5 {
+			t.Errorf("page %d has %d of the title's 5 rows", i+1, rows)
+		}
+	}
+}
@@ -0,0 +1,226 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// releaseName picks the name to title a revision range with: the upper end
+// of "v1.2.0..v1.3.0", or the revision itself when it is not a range.
+func releaseName(rev string) string {
+	if i := strings.LastIndex(rev, ".."); i >= 0 {
+		rev = strings.TrimLeft(rev[i:], ".")
+		if rev == "" {
+			rev = "HEAD"
+		}
+	}
+	if rev == "HEAD" {
+		return ""
+	}
+	return rev
+}
+
+// revisionTips returns the commits rev includes history from, leaving out
+// the excluded ends of a range: "v1.2.0..v1.3.0" gives v1.3.0's commit,
+// and "a...b" gives b's and then a's.
+func revisionTips(ctx context.Context, dir, rev string) ([]string, error) {
+	out, err := runCommand(ctx, dir, "git", "rev-parse", rev)
+	if err != nil {
+		return nil, err
+	}
+	var tips []string
+	for _, line := range strings.Fields(string(out)) {
+		if !strings.HasPrefix(line, "^") {
+			tips = append(tips, line)
+		}
+	}
+	return tips, nil
+}
+
+// treeRevision returns the commit whose files describe the credited
+// history: the upper end of rev, or HEAD when no revision is given.
+func treeRevision(ctx context.Context, dir, rev string) string {
+	if rev != "" {
+		if tips, err := revisionTips(ctx, dir, rev); err == nil && len(tips) > 0 {
+			return tips[0]
+		}
+	}
+	return "HEAD"
+}
+
+// verifyRevision checks that rev names commits in the repository at dir,
+// so a mistyped range fails before any history is collected.
+func verifyRevision(ctx context.Context, dir, rev string) error {
+	if _, err := runCommand(ctx, dir, "git", "rev-list", "--max-count=1", rev, "--"); err != nil {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		return fmt.Errorf("invalid revision range %q: %w", rev, err)
+	}
+	return nil
+}
+
+// isRevisionRange reports whether arg is a revision range such as
+// "v1.2.0..v1.3.0" in the repository at dir. Git decides, so a path that
+// merely contains ".." is not mistaken for one.
+func isRevisionRange(dir, arg string) bool {
+	if !strings.Contains(arg, "..") {
+		return false
+	}
+	out, err := runCommand(context.Background(), dir, "git", "rev-parse", "--revs-only", arg)
+	return err == nil && strings.TrimSpace(string(out)) != ""
+}
+
+// tagSubject returns the message subject of an annotated tag, or "" for
+// lightweight tags and other revisions.
+func tagSubject(ctx context.Context, dir, name string) string {
+	if name == "" {
+		return ""
+	}
+	out, err := runCommand(ctx, dir, "git", "for-each-ref", "--format=%(objecttype)%00%(contents:subject)", "refs/tags/"+name)
+	if err != nil {
+		return ""
+	}
+	kind, subject, ok := strings.Cut(strings.TrimSpace(string(out)), "\x00")
+	if !ok || kind != "tag" {
+		return ""
+	}
+	return strings.TrimSpace(subject)
+}
+
+// priorIdentities collects the normalized emails and names of everyone who
+// authored or co-authored a commit excluded by the range (the "^v1.2.0" side
+// of "v1.2.0..v1.3.0"), limited to paths when given. It returns nil when rev
+// has no excluded side.
+func priorIdentities(ctx context.Context, dir, rev string, paths []string) map[string]bool {
+	out, err := runCommand(ctx, dir, "git", "rev-parse", rev)
+	if err != nil {
+		return nil
+	}
+	var bases []string
+	for _, line := range strings.Split(strings.TrimSpace(string(out)), "\n") {
+		if strings.HasPrefix(line, "^") {
+			bases = append(bases, line[1:])
+		}
+	}
+	if len(bases) == 0 {
+		return nil
+	}
+
+	args := append([]string{"log", "--format=%aN%x00%aE%x00%(trailers:key=Co-authored-by,valueonly,unfold,separator=%x00)"}, bases...)
+	if len(paths) > 0 {
+		args = append(append(args, "--"), paths...)
+	}
+	prior := make(map[string]bool)
+	remember := func(name, email string) {
+		if n := normalizeName(name); n != "" {
+			prior["name:"+n] = true
+		}
+		if e := normalizeEmail(email); e != "" {
+			prior["email:"+e] = true
+		}
+	}
+	err = streamLines(ctx, dir, func(line string) {
+		fields := strings.Split(line, "\x00")
+		if len(fields) < 2 {
+			return
+		}
+		remember(fields[0], fields[1])
+		for _, ident := range fields[2:] {
+			remember(parseIdent(ident))
+		}
+	}, "git", args...)
+	if err != nil {
+		return nil
+	}
+	return prior
+}
+
+// markNewcomers flags contributors none of whose aliases appear in prior.
+func markNewcomers(contributors []contributor, prior map[string]bool) {
+	if prior == nil {
+		return
+	}
+	for i := range contributors {
+		c := &contributors[i]
+		known := prior["name:"+normalizeName(c.name)]
+		for _, e := range c.emails {
+			known = known || prior["email:"+normalizeEmail(e)]
+		}
+		c.newcomer = !known
+	}
+}
+
+// careerStart is the earliest authored commit of one identity.
+type careerStart struct {
+	first time.Time
+	debut string
+}
+
+// careerStarts finds everyone's first authored commit in the whole history
+// behind rev, ignoring the range's excluded side and any time window, so a
+// scoped run still shows a contributor's real debut. Results are keyed like
+// priorIdentities.
+func careerStarts(ctx context.Context, dir, rev string, paths []string) map[string]careerStart {
+	if rev == "" {
+		rev = "HEAD"
+	}
+	tips, err := revisionTips(ctx, dir, rev)
+	if err != nil {
+		return nil
+	}
+	args := append([]string{"log", "--no-merges", "--format=%at%x00%aN%x00%aE%x00%s"}, tips...)
+	if len(paths) > 0 {
+		args = append(append(args, "--"), paths...)
+	}
+
+	starts := make(map[string]careerStart)
+	remember := func(key string, s careerStart) {
+		// git log lists newest first, so older commits overwrite
+		if old, ok := starts[key]; !ok || !s.first.After(old.first) {
+			starts[key] = s
+		}
+	}
+	err = streamLines(ctx, dir, func(line string) {
+		fields := strings.SplitN(line, "\x00", 4)
+		if len(fields) < 4 {
+			return
+		}
+		sec, err := strconv.ParseInt(fields[0], 10, 64)
+		if err != nil {
+			return
+		}
+		s := careerStart{first: time.Unix(sec, 0), debut: fields[3]}
+		if n := normalizeName(fields[1]); n != "" {
+			remember("name:"+n, s)
+		}
+		if e := normalizeEmail(fields[2]); e != "" {
+			remember("email:"+e, s)
+		}
+	}, "git", args...)
+	if err != nil {
+		return nil
+	}
+	return starts
+}
+
+// applyCareerStarts moves each contributor's first commit and debut back to
+// the earliest one found under any of their aliases.
+func applyCareerStarts(contributors []contributor, starts map[string]careerStart) {
+	for i := range contributors {
+		c := &contributors[i]
+		keys := []string{"name:" + normalizeName(c.name)}
+		for _, e := range c.emails {
+			keys = append(keys, "email:"+normalizeEmail(e))
+		}
+		for _, k := range keys {
+			if s, ok := starts[k]; ok && (c.first.IsZero() || s.first.Before(c.first)) {
+				c.first = s.first
+				c.debut = s.debut
+			}
+		}
+	}
+}
@@ -0,0 +1,81 @@
+package main
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestReleaseName(t *testing.T) {
+	tests := map[string]string{
+		"v1.2.0..v1.3.0": "v1.3.0",
+		"v1.2.0..":       "",
+		"v2.0.0":         "v2.0.0",
+		"HEAD":           "",
+	}
+	for rev, want := range tests {
+		if got := releaseName(rev); got != want {
+			t.Errorf("releaseName(%q) = %q, want %q", rev, got, want)
+		}
+	}
+}
+
+func TestParseArgs_PositionalRange(t *testing.T) {
+	repoDir := setupTestRepo(t)
+	runInDir(t, repoDir, "git", "tag", "v1.2.0")
+	commitAs(t, repoDir, "Test User", "test@example.com", "feat: next")
+	runInDir(t, repoDir, "git", "tag", "v1.3.0")
+
+	cfg, err := parseArgs([]string{"v1.2.0..v1.3.0", repoDir})
+	if err != nil {
+		t.Fatalf("parseArgs returned error: %v", err)
+	}
+	if cfg.rev != "v1.2.0..v1.3.0" || len(cfg.dirs) != 1 || cfg.dirs[0] != repoDir {
+		t.Fatalf("expected positional range, got %+v", cfg)
+	}
+
+	for _, arg := range []string{"../typo", "v1.2.0..v9.9.9"} {
+		if _, err := parseArgs([]string{repoDir, arg}); err == nil || !strings.Contains(err.Error(), "neither a directory nor a revision range") {
+			t.Errorf("parseArgs(%q) should reject the argument, got %v", arg, err)
+		}
+	}
+}
+
+func TestGetRepoInfo_InvalidRange(t *testing.T) {
+	repoDir := setupTestRepo(t)
+	_, err := getRepoInfo(context.Background(), repoDir, repoOptions{rev: "v1.0.0..v9.9.9"})
+	if err == nil || !strings.Contains(err.Error(), "invalid revision range") {
+		t.Fatalf("expected an invalid range error, got %v", err)
+	}
+}
+
+func TestGetRepoInfo_ReleaseRange(t *testing.T) {
+	repoDir := setupTestRepo(t)
+	runInDir(t, repoDir, "git", "tag", "v1.0.0")
+	commitAs(t, repoDir, "Test User", "test@example.com", "feat: returning author")
+	commitAs(t, repoDir, "Test User", "test@example.com", "feat: more from the lead")
+	commitAs(t, repoDir, "Nina New", "nina@example.com", "fix: first patch")
+	runInDir(t, repoDir, "git", "tag", "-a", "v1.1.0", "-m", "The one with the fixes")
+	commitAs(t, repoDir, "Test User", "test@example.com", "feat: after the release")
+
+	info, err := getRepoInfo(context.Background(), repoDir, repoOptions{rev: "v1.0.0..v1.1.0"})
+	if err != nil {
+		t.Fatalf("getRepoInfo returned error: %v", err)
+	}
+	if info.totalCommits != 3 {
+		t.Fatalf("expected 3 commits in range, got %d", info.totalCommits)
+	}
+	if info.release != "v1.1.0" || info.releaseNote != "The one with the fixes" {
+		t.Fatalf("unexpected release title: %q / %q", info.release, info.releaseNote)
+	}
+	for _, c := range info.contributors {
+		if c.newcomer != (c.name == "Nina New") {
+			t.Errorf("unexpected newcomer flag for %+v", c)
+		}
+	}
+
+	text := strings.Join(buildCredits(info, blockFont, 80), "\n")
+	if !strings.Contains(text, "v1.1.0") || !strings.Contains(text, "INTRODUCING") {
+		t.Error("credits should show the release name and introduce first-time contributors")
+	}
+}
@@ -0,0 +1,67 @@
+package main
+
+import (
+	"context"
+	"net/url"
+	"strings"
+)
+
+// remoteURL is the parsed form of a git remote such as
+// "git@github.com:owner/repo.git" or "https://gitlab.com/group/sub/repo".
+type remoteURL struct {
+	host string // e.g. "github.com", without port
+	path string // e.g. "owner/repo", without ".git"
+}
+
+// name returns the repository name, the last element of the path.
+func (r remoteURL) name() string {
+	if i := strings.LastIndexByte(r.path, '/'); i >= 0 {
+		return r.path[i+1:]
+	}
+	return r.path
+}
+
+// parseRemoteURL understands URL-style remotes and scp-style
+// "user@host:path" remotes. Local paths yield an empty host.
+func parseRemoteURL(raw string) (remoteURL, bool) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return remoteURL{}, false
+	}
+
+	var r remoteURL
+	if strings.Contains(raw, "://") {
+		u, err := url.Parse(raw)
+		if err != nil {
+			return remoteURL{}, false
+		}
+		r.host = u.Hostname()
+		r.path = u.Path
+	} else if colon := strings.IndexByte(raw, ':'); colon > 1 && !strings.ContainsAny(raw[:colon], "/\\") {
+		// scp-style; a single letter before the colon is a Windows drive
+		host := raw[:colon]
+		if at := strings.LastIndexByte(host, '@'); at >= 0 {
+			host = host[at+1:]
+		}
+		r.host = host
+		r.path = raw[colon+1:]
+	} else {
+		r.path = strings.ReplaceAll(raw, "\\", "/")
+	}
+
+	r.path = strings.Trim(r.path, "/")
+	r.path = strings.TrimSuffix(r.path, ".git")
+	if r.path == "" {
+		return remoteURL{}, false
+	}
+	return r, true
+}
+
+// originRemote reads and parses the "origin" remote of the repository.
+func originRemote(ctx context.Context, dir string) (remoteURL, bool) {
+	out, err := runCommand(ctx, dir, "git", "config", "--get", "remote.origin.url")
+	if err != nil {
+		return remoteURL{}, false
+	}
+	return parseRemoteURL(string(out))
+}
@@ -0,0 +1,63 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+// trailerRole maps a commit trailer key to the department it credits.
+type trailerRole struct {
+	trailer string // e.g. "Reviewed-by"
+	title   string // e.g. "REVIEWED BY"
+}
+
+// roleCredit is one department section: everyone named in a trailer kind.
+type roleCredit struct {
+	title  string
+	people []contributor // commits counts how often each person was named
+}
+
+func defaultTrailerRoles() []trailerRole {
+	return []trailerRole{
+		{trailer: "Reviewed-by", title: "REVIEWED BY"},
+		{trailer: "Tested-by", title: "TESTED BY"},
+		{trailer: "Reported-by", title: "REPORTED BY"},
+		{trailer: "Suggested-by", title: "SUGGESTED BY"},
+	}
+}
+
+// parseTrailerRole parses a --role value of the form "Acked-by=ACKED BY".
+// An empty title ("Tested-by=") removes that trailer from the mapping.
+func parseTrailerRole(s string) (trailerRole, error) {
+	key, title, ok := strings.Cut(s, "=")
+	key = strings.TrimSpace(key)
+	if !ok || key == "" || strings.ContainsAny(key, " :") {
+		return trailerRole{}, fmt.Errorf("invalid role %q, expected Trailer-Key=TITLE", s)
+	}
+	return trailerRole{trailer: key, title: strings.ToUpper(strings.TrimSpace(title))}, nil
+}
+
+// mergeTrailerRoles applies overrides on top of base, keeping base order
+// and appending new trailers at the end.
+func mergeTrailerRoles(base, overrides []trailerRole) []trailerRole {
+	roles := append([]trailerRole(nil), base...)
+	for _, o := range overrides {
+		replaced := false
+		for i := range roles {
+			if strings.EqualFold(roles[i].trailer, o.trailer) {
+				roles[i].title = o.title
+				replaced = true
+			}
+		}
+		if !replaced {
+			roles = append(roles, o)
+		}
+	}
+	active := roles[:0]
+	for _, r := range roles {
+		if r.title != "" {
+			active = append(active, r)
+		}
+	}
+	return active
+}
@@ -0,0 +1,84 @@
+package main
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestParseTrailerRole(t *testing.T) {
+	role, err := parseTrailerRole("Acked-by=acked by")
+	if err != nil {
+		t.Fatalf("parseTrailerRole returned error: %v", err)
+	}
+	if role.trailer != "Acked-by" || role.title != "ACKED BY" {
+		t.Fatalf("unexpected role: %+v", role)
+	}
+	if _, err := parseTrailerRole("Acked-by"); err == nil {
+		t.Fatal("expected error for missing title")
+	}
+}
+
+func TestMergeTrailerRoles(t *testing.T) {
+	roles := mergeTrailerRoles(defaultTrailerRoles(), []trailerRole{
+		{trailer: "tested-by", title: ""},
+		{trailer: "Acked-by", title: "ACKED BY"},
+	})
+	var titles []string
+	for _, r := range roles {
+		titles = append(titles, r.title)
+	}
+	got := strings.Join(titles, ",")
+	want := "REVIEWED BY,REPORTED BY,SUGGESTED BY,ACKED BY"
+	if got != want {
+		t.Fatalf("mergeTrailerRoles = %q, want %q", got, want)
+	}
+}
+
+func TestGetRepoInfo_TrailerRoles(t *testing.T) {
+	repoDir := setupTestRepo(t)
+	commitAs(t, repoDir, "Test User", "test@example.com",
+		"fix: crash\n\nReported-by: Rita <rita@example.com>\nReviewed-by: Rex <rex@example.com>")
+	commitAs(t, repoDir, "Test User", "test@example.com",
+		"fix: another crash\n\nReviewed-by: Rex <rex@example.com>")
+
+	info, err := getRepoInfo(context.Background(), repoDir, repoOptions{roles: defaultTrailerRoles()})
+	if err != nil {
+		t.Fatalf("getRepoInfo returned error: %v", err)
+	}
+	if len(info.roles) != 2 {
+		t.Fatalf("expected 2 role sections, got %+v", info.roles)
+	}
+	if info.roles[0].title != "REVIEWED BY" || info.roles[0].people[0].name != "Rex" || info.roles[0].people[0].commits != 2 {
+		t.Fatalf("unexpected reviewed-by section: %+v", info.roles[0])
+	}
+	if len(info.contributors) != 1 {
+		t.Fatalf("role trailers should not add cast members, got %+v", info.contributors)
+	}
+}
+
+func TestBuildCredits_RoleSections(t *testing.T) {
+	info := repoInfo{
+		name:         "test",
+		totalCommits: 3,
+		contributors: []contributor{{name: "Alice", commits: 3}},
+		roles: []roleCredit{
+			{title: "TESTED BY", people: []contributor{{name: "Tess", commits: 1}}},
+		},
+	}
+	text := strings.Join(buildCredits(info, blockFont, 80), "\n")
+	if !strings.Contains(text, "T E S T E D   B Y") || !strings.Contains(text, "TESS") {
+		t.Error("credits should contain a TESTED BY section naming Tess")
+	}
+
+	cards := buildMatrixCards(info, blockFont, 80, 24)
+	found := false
+	for _, card := range cards {
+		if strings.Contains(strings.Join(card.lines, "\n"), "T E S T E D   B Y") {
+			found = true
+		}
+	}
+	if !found {
+		t.Error("matrix cards should include a TESTED BY card")
+	}
+}
@@ -0,0 +1,203 @@
+package main
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// sanitizeText makes text taken from commits, trailers, tags and forges
+// safe to draw. Escape sequences are dropped with their payload, so an OSC
+// window title or a CSI cursor move never reaches the terminal. Remaining
+// C0 and C1 controls are shown as U+FFFD, whitespace controls become
+// spaces, and invisible format characters (bidi overrides and
+// isolates, zero-width spaces, soft hyphens, tag characters) are removed
+// so what is drawn is what was written. Zero-width joiners and non-joiners
+// are kept only inside emoji sequences and between letters of the scripts
+// whose shaping they change, such as Persian and Indic names; elsewhere,
+// as in "Ali\u200dce", they would only hide a lookalike.
+func sanitizeText(s string) string {
+	if isPlainText(s) {
+		return s
+	}
+	var b strings.Builder
+	b.Grow(len(s))
+	prev := rune(-1) // last rune written
+	for i := 0; i < len(s); {
+		r, size := utf8.DecodeRuneInString(s[i:])
+		switch {
+		case r == '\u200c' || r == '\u200d':
+			if next, _ := utf8.DecodeRuneInString(s[i+size:]); keepsJoiner(prev, next) {
+				b.WriteRune(r)
+			}
+		case r == utf8.RuneError && size == 1:
+			b.WriteRune(utf8.RuneError)
+		case r == 0x1b:
+			size = escapeLength(s[i:])
+		case r == 0x9b || r == 0x9d || r == 0x90 || r == 0x98 || r == 0x9e || r == 0x9f:
+			// 8-bit CSI, OSC, DCS, SOS, PM and APC
+			size += stringPayloadLength(s[i+size:], r == 0x9b)
+		case r == '\t' || r == '\n' || r == '\r' || r == '\v' || r == '\f' ||
+			r == '\u2028' || r == '\u2029' || r == '\u0085':
+			b.WriteByte(' ')
+		case unicode.IsControl(r):
+			b.WriteRune(utf8.RuneError)
+		case unicode.Is(unicode.Cf, r):
+			// format characters draw nothing on their own
+		default:
+			b.WriteString(s[i : i+size])
+		}
+		if r != '\u200c' && r != '\u200d' {
+			prev = r
+		}
+		i += size
+	}
+	return b.String()
+}
+
+// joiningScripts are the scripts where a zero-width joiner or non-joiner
+// changes how letters are drawn: Arabic-style cursive joining and Indic
+// conjuncts.
+var joiningScripts = []*unicode.RangeTable{
+	unicode.Arabic, unicode.Syriac, unicode.Nko, unicode.Mongolian,
+	unicode.Devanagari, unicode.Bengali, unicode.Gurmukhi, unicode.Gujarati, unicode.Oriya,
+	unicode.Tamil, unicode.Telugu, unicode.Kannada, unicode.Malayalam, unicode.Sinhala,
+}
+
+// keepsJoiner reports whether a zero-width joiner or non-joiner between
+// prev and next means something: both sides belong to an emoji sequence,
+// or both are in a joining script.
+func keepsJoiner(prev, next rune) bool {
+	return (isEmojiPart(prev) && isEmojiPart(next)) ||
+		(unicode.In(prev, joiningScripts...) && unicode.In(next, joiningScripts...))
+}
+
+// isEmojiPart reports whether r can sit inside an emoji ZWJ sequence: a
+// pictographic symbol, a skin tone modifier or the emoji variation
+// selector.
+func isEmojiPart(r rune) bool {
+	if r == utf8.RuneError {
+		return false
+	}
+	return unicode.Is(unicode.So, r) || (r >= 0x1f3fb && r <= 0x1f3ff) || r == '\ufe0f'
+}
+
+// isPlainText reports whether s is printable ASCII, the common case.
+func isPlainText(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] < 0x20 || s[i] >= 0x7f {
+			return false
+		}
+	}
+	return true
+}
+
+// escapeLength returns the length of the escape sequence at the start of
+// s, which begins with ESC.
+func escapeLength(s string) int {
+	if len(s) < 2 {
+		return len(s)
+	}
+	switch s[1] {
+	case '[':
+		return 2 + stringPayloadLength(s[2:], true)
+	case ']', 'P', 'X', '^', '_':
+		return 2 + stringPayloadLength(s[2:], false)
+	}
+	// a two-byte sequence such as ESC c (reset); intermediates first
+	n := 1
+	for n < len(s) && s[n] >= 0x20 && s[n] <= 0x2f {
+		n++
+	}
+	if n < len(s) && s[n] >= 0x30 && s[n] <= 0x7e {
+		n++
+	}
+	return n
+}
+
+// stringPayloadLength returns how much of s belongs to a control sequence
+// that has just been introduced. A CSI ends at its final byte; OSC, DCS and
+// the other string sequences end at BEL or ST, or run to the end of s.
+func stringPayloadLength(s string, csi bool) int {
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if csi {
+			if c >= 0x40 && c <= 0x7e {
+				return i + 1
+			}
+			if c < 0x20 || c > 0x3f {
+				return i // malformed; stop before it
+			}
+			continue
+		}
+		switch {
+		case c == 0x07:
+			return i + 1
+		case c == 0x1b && i+1 < len(s) && s[i+1] == '\\':
+			return i + 2
+		case c == 0xc2 && i+1 < len(s) && s[i+1] == 0x9c:
+			return i + 2
+		}
+	}
+	return len(s)
+}
+
+// sanitizeRepoInfo applies sanitizeText to every string the renderers
+// draw.
+func sanitizeRepoInfo(info repoInfo) repoInfo {
+	info.name = sanitizeText(info.name)
+	info.description = sanitizeText(info.description)
+	info.license = sanitizeText(info.license)
+	info.language = sanitizeText(info.language)
+	info.window = sanitizeText(info.window)
+	info.release = sanitizeText(info.release)
+	info.releaseNote = sanitizeText(info.releaseNote)
+	info.contributors = sanitizeContributors(info.contributors)
+	info.bots = sanitizeContributors(info.bots)
+
+	if info.roles != nil {
+		roles := make([]roleCredit, len(info.roles))
+		for i, r := range info.roles {
+			roles[i] = roleCredit{title: sanitizeText(r.title), people: sanitizeContributors(r.people)}
+		}
+		info.roles = roles
+	}
+	if info.highlights != nil {
+		highlights := make([]highlight, len(info.highlights))
+		for i, h := range info.highlights {
+			h.kind = sanitizeText(h.kind)
+			h.scope = sanitizeText(h.scope)
+			h.description = sanitizeText(h.description)
+			highlights[i] = h
+		}
+		info.highlights = highlights
+	}
+	return info
+}
+
+func sanitizeContributors(cs []contributor) []contributor {
+	if cs == nil {
+		return nil
+	}
+	out := make([]contributor, len(cs))
+	for i, c := range cs {
+		c.name = sanitizeText(c.name)
+		c.debut = sanitizeText(c.debut)
+		if c.emails != nil {
+			emails := make([]string, len(c.emails))
+			for j, e := range c.emails {
+				emails[j] = sanitizeText(e)
+			}
+			c.emails = emails
+		}
+		if c.repos != nil {
+			repos := make([]repoShare, len(c.repos))
+			for j, r := range c.repos {
+				repos[j] = repoShare{repo: sanitizeText(r.repo), commits: r.commits}
+			}
+			c.repos = repos
+		}
+		out[i] = c
+	}
+	return out
+}
@@ -0,0 +1,80 @@
+package main
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestSanitizeText(t *testing.T) {
+	tests := []struct {
+		name, in, want string
+	}{
+		{"plain", "fix: handle empty input", "fix: handle empty input"},
+		{"csi colour", "\x1b[31mred\x1b[0m alert", "red alert"},
+		{"csi clear screen", "a\x1b[2J\x1b[Hb", "ab"},
+		{"osc title bel", "\x1b]0;pwned\x07subject", "subject"},
+		{"osc hyperlink st", "\x1b]8;;https://evil.example\x1b\\click\x1b]8;;\x1b\\", "click"},
+		{"unterminated osc", "ok\x1b]0;rest of the line", "ok"},
+		{"two-byte escape", "reset\x1bc here", "reset here"},
+		{"c1 csi", "x\u009b2Jy", "xy"},
+		{"c1 osc", "x\u009d0;title\u009cy", "xy"},
+		{"carriage return overwrite", "harmless\rEVIL", "harmless EVIL"},
+		{"newline and tab", "one\ntwo\tthree", "one two three"},
+		{"bell and backspace", "ding\x07\x08", "ding��"},
+		{"nul and del", "a\x00b\x7fc", "a�b�c"},
+		{"bidi override", "user\u202egnp.exe", "usergnp.exe"},
+		{"bidi isolate", "\u2067admin\u2069", "admin"},
+		{"zero width", "Ali\u200bce\u200d\ufeff", "Alice"},
+		{"leading joiner", "\u200dAlice\u200c", "Alice"},
+		{"joiner between spaces", "a \u200d b", "a  b"},
+		{"persian non-joiner", "\u0645\u06cc\u200c\u062e\u0648\u0627\u0647\u0645", "\u0645\u06cc\u200c\u062e\u0648\u0627\u0647\u0645"},
+		{"devanagari joiner", "\u0915\u094d\u200d\u0937", "\u0915\u094d\u200d\u0937"},
+		{"emoji sequence", "\U0001f469\u200d\U0001f4bb Kim", "\U0001f469\u200d\U0001f4bb Kim"},
+		{"emoji with skin tone", "\U0001f469\U0001f3fd\u200d\U0001f4bb", "\U0001f469\U0001f3fd\u200d\U0001f4bb"},
+		{"emoji with variation selector", "\u2764\ufe0f\u200d\U0001f525", "\u2764\ufe0f\u200d\U0001f525"},
+		{"joiner between latin letters", "Ali\u200dce", "Alice"},
+		{"non-joiner between cyrillic letters", "\u0410\u200c\u043b\u0438\u0441\u0430", "\u0410\u043b\u0438\u0441\u0430"},
+		{"joiner between greek and emoji", "\u03b1\u200d\U0001f4bb", "\u03b1\U0001f4bb"},
+		{"soft hyphen", "in\u00advisible", "invisible"},
+		{"tag characters", "flag\U000E0041\U000E007F", "flag"},
+		{"line separator", "a\u2028b", "a b"},
+		{"invalid utf-8", "bad\xffbyte", "bad�byte"},
+		{"accents and emoji kept", "José 🎬 日本", "José 🎬 日本"},
+	}
+	for _, tt := range tests {
+		if got := sanitizeText(tt.in); got != tt.want {
+			t.Errorf("%s: sanitizeText(%q) = %q, want %q", tt.name, tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestCollectRepoInfo_HostileHistory(t *testing.T) {
+	repoDir := setupTestRepo(t)
+	commitAs(t, repoDir, "Mallory\x1b]0;owned\x07\u202e", "mallory@example.com",
+		"feat(\x1b[2Japi): steal \x1b[31mthe show\x1b[0m\rfake\u200b")
+	runInDir(t, repoDir, "git", "tag", "-a", "v1.0.0", "-m", "Release \x1b[5mblink\x1b[0m")
+
+	info, err := collectRepoInfo(context.Background(), []string{repoDir}, repoOptions{offline: true, rev: "v1.0.0"}, "")
+	if err != nil {
+		t.Fatalf("collectRepoInfo returned error: %v", err)
+	}
+
+	text := strings.Join(buildCredits(info, blockFont, 80), "\n")
+	for _, c := range cardLines(buildMatrixCards(info, blockFont, 80, 24)) {
+		text += "\n" + c
+	}
+	for _, c := range cardLines(buildSpidermanCards(info, blockFont, 80, 24)) {
+		text += "\n" + c
+	}
+	for _, r := range text {
+		if r == '\x1b' || r == '\r' || r == '\u202e' || r == '\u200b' || (r >= 0x80 && r <= 0x9f) {
+			t.Fatalf("credits contain control character %U", r)
+		}
+	}
+	for _, want := range []string{"MALLORY", "steal the show fake", "Release blink"} {
+		if !strings.Contains(text, want) {
+			t.Errorf("credits should contain %q", want)
+		}
+	}
+}
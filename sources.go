@@ -0,0 +1,78 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Per-source time limits. Git history has none by default because large
+// repositories legitimately take a while; network-backed metadata does.
+const (
+	metadataTimeout = 10 * time.Second
+)
+
+// source is one independent piece of repository data collection.
+type source struct {
+	name    string
+	timeout time.Duration // zero means only the parent context applies
+	run     func(ctx context.Context) error
+}
+
+// sourceFailure records a source that could not be collected, so the caller
+// can say what is missing instead of silently leaving it blank.
+type sourceFailure struct {
+	source string
+	err    error
+}
+
+func (f sourceFailure) String() string {
+	return fmt.Sprintf("%s: %v", f.source, f.err)
+}
+
+// runSources runs every source concurrently and waits for all of them.
+// Each source writes only its own results, so no locking is needed beyond
+// collecting failures.
+func runSources(ctx context.Context, sources []source) []sourceFailure {
+	var (
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+		failures []sourceFailure
+	)
+	for _, s := range sources {
+		wg.Add(1)
+		go func(s source) {
+			defer wg.Done()
+			sctx, cancel := ctx, context.CancelFunc(func() {})
+			if s.timeout > 0 {
+				sctx, cancel = context.WithTimeout(ctx, s.timeout)
+			}
+			defer cancel()
+
+			err := s.run(sctx)
+			if err == nil {
+				return
+			}
+			if errors.Is(sctx.Err(), context.DeadlineExceeded) && ctx.Err() == nil {
+				err = fmt.Errorf("timed out after %s", s.timeout)
+			}
+			mu.Lock()
+			failures = append(failures, sourceFailure{source: s.name, err: err})
+			mu.Unlock()
+		}(s)
+	}
+	wg.Wait()
+
+	// report in declaration order rather than completion order
+	ordered := make([]sourceFailure, 0, len(failures))
+	for _, s := range sources {
+		for _, f := range failures {
+			if f.source == s.name {
+				ordered = append(ordered, f)
+			}
+		}
+	}
+	return ordered
+}
@@ -0,0 +1,49 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRunSources(t *testing.T) {
+	var got string
+	failures := runSources(context.Background(), []source{
+		{name: "slow", timeout: 20 * time.Millisecond, run: func(ctx context.Context) error {
+			<-ctx.Done()
+			return ctx.Err()
+		}},
+		{name: "ok", run: func(ctx context.Context) error {
+			got = "done"
+			return nil
+		}},
+		{name: "broken", run: func(ctx context.Context) error {
+			return errors.New("exit status 1")
+		}},
+	})
+
+	if got != "done" {
+		t.Errorf("successful source did not run")
+	}
+	if len(failures) != 2 {
+		t.Fatalf("failures = %v, want 2", failures)
+	}
+	if failures[0].source != "slow" || !strings.Contains(failures[0].err.Error(), "timed out") {
+		t.Errorf("failures[0] = %v, want slow source timed out", failures[0])
+	}
+	if failures[1].String() != "broken: exit status 1" {
+		t.Errorf("failures[1] = %q", failures[1].String())
+	}
+}
+
+func TestGetRepoInfo_Cancelled(t *testing.T) {
+	repoDir := setupTestRepo(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := getRepoInfo(ctx, repoDir, repoOptions{}); !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context.Canceled, got %v", err)
+	}
+}
@@ -31,52 +31,49 @@ func spiderTitle(rank int, commits int) string {
 // Glitch characters
 var glitchChars = []rune("█▓▒░▀▄▌▐╔╗╚╝═║╬╣╠╩╦┃━┏┓┗┛")
 
-// glitchLine applies random glitch distortion to a string
+// glitchLine applies random glitch distortion to a string. A wide glyph
+// is replaced by two glitch characters so the line keeps its width.
 func glitchLine(s string, intensity float64) string {
-	runes := []rune(s)
-	result := make([]rune, len(runes))
-	for i, r := range runes {
-		if r == ' ' {
-			result[i] = r
+	var b strings.Builder
+	for _, cell := range textCells(s) {
+		if cell == "" || cell == " " || rand.Float64() >= intensity {
+			b.WriteString(cell)
 			continue
 		}
-		if rand.Float64() < intensity {
-			result[i] = glitchChars[rand.Intn(len(glitchChars))]
-		} else {
-			result[i] = r
+		for i := displayWidth(cell); i > 0; i-- {
+			b.WriteRune(glitchChars[rand.Intn(len(glitchChars))])
 		}
 	}
-	return string(result)
+	return b.String()
 }
 
-// rgbShift shifts text left/right to simulate chromatic aberration
+// rgbShift shifts text left/right to simulate chromatic aberration. It
+// works in screen cells, keeping both halves of a wide glyph together.
 func rgbShift(s string, offset int) (string, string) {
-	runes := []rune(s)
-	red := make([]rune, len(runes))
-	blue := make([]rune, len(runes))
-
-	for i := range runes {
-		red[i] = ' '
-		blue[i] = ' '
-	}
-
-	for i, r := range runes {
-		if r == ' ' {
-			continue
+	cells := textCells(s)
+	shift := func(by int) string {
+		out := make([]string, len(cells))
+		for i := range out {
+			out[i] = " "
 		}
-		// Red channel: shift left
-		ri := i - offset
-		if ri >= 0 && ri < len(red) {
-			red[ri] = r
-		}
-		// Blue channel: shift right
-		bi := i + offset
-		if bi >= 0 && bi < len(blue) {
-			blue[bi] = r
+		for i, cell := range cells {
+			if !cellHasText(cell) {
+				continue
+			}
+			j := i + by
+			wide := i+1 < len(cells) && cells[i+1] == ""
+			if j < 0 || j >= len(out) || (wide && j+1 >= len(out)) {
+				continue
+			}
+			out[j] = cell
+			if wide {
+				out[j+1] = ""
+			}
 		}
+		return joinCells(out)
 	}
-
-	return string(red), string(blue)
+	// Red channel shifts left, blue channel right
+	return shift(-offset), shift(offset)
 }
 
 // Web pattern for background
@@ -107,122 +104,124 @@ func newWebField(width, totalHeight int) webField {
 	return wf
 }
 
-func buildSpidermanCards(info repoInfo, width, height int) []matrixCard {
+func buildSpidermanCards(info repoInfo, titleFont *font, width, height int) []matrixCard {
 	var cards []matrixCard
+	doc := buildCreditsDoc(info)
 
-	center := func(s string) string {
-		return centerText(s, width)
-	}
-
-	makeCard := func(content []string) matrixCard {
-		lines := make([]string, height)
-		startY := (height - len(content)) / 2
-		if startY < 0 {
-			startY = 0
-		}
-		for i, line := range content {
-			if startY+i < height {
-				lines[startY+i] = line
-			}
-		}
-		return matrixCard{lines: lines}
-	}
-
-	// Card 0: Title
-	var titleContent []string
-	titleContent = append(titleContent, center("━━━━━━━━━━━━━━━━━━━━"))
-	titleContent = append(titleContent, "")
-	titleRows := bigText(info.name)
-	for _, row := range titleRows {
-		titleContent = append(titleContent, center(row))
+	line := func(kind creditKind, s string) creditLine {
+		return creditLine{kind: kind, text: s}
 	}
-	titleContent = append(titleContent, "")
-	if info.description != "" {
-		titleContent = append(titleContent, center("\""+info.description+"\""))
-		titleContent = append(titleContent, "")
+	blank := creditLine{}
+	add := func(c cardContent) {
+		cards = append(cards, c.paginate(width, height)...)
 	}
-	if info.language != "" || info.stars > 0 {
-		var meta []string
-		if info.language != "" {
-			meta = append(meta, info.language)
+	bordered := func(header, body []creditLine) cardContent {
+		return cardContent{
+			header: append([]creditLine{line(kindDivider, "━━━━━━━━━━━━━━━━━━━━"), blank}, header...),
+			body:   body,
+			footer: []creditLine{blank, line(kindDivider, "━━━━━━━━━━━━━━━━━━━━")},
 		}
-		if info.stars > 0 {
-			meta = append(meta, fmt.Sprintf("★ %d stars", info.stars))
-		}
-		titleContent = append(titleContent, center("· "+strings.Join(meta, " · ")+" ·"))
-	}
-	titleContent = append(titleContent, "")
-	titleContent = append(titleContent, center("━━━━━━━━━━━━━━━━━━━━"))
-	cards = append(cards, makeCard(titleContent))
-
-	// Contributor cards
-	for i, c := range info.contributors {
-		title := spiderTitle(i, c.commits)
-		var content []string
-		content = append(content, center("━━━━━━━━━━━━━━━━━━━━"))
-		content = append(content, "")
-		content = append(content, center(title))
-		content = append(content, "")
-		content = append(content, center(strings.ToUpper(c.name)))
-		content = append(content, "")
-		content = append(content, center(fmt.Sprintf("%d webs spun", c.commits)))
-		content = append(content, "")
-		content = append(content, center("━━━━━━━━━━━━━━━━━━━━"))
-		cards = append(cards, makeCard(content))
 	}
 
-	// Notable commits card
-	if len(info.highlights) > 0 {
-		var hlContent []string
-		hlContent = append(hlContent, center("━━━━━━━━━━━━━━━━━━━━"))
-		hlContent = append(hlContent, "")
-		hlContent = append(hlContent, center("N O T A B L E   C O M M I T S"))
-		hlContent = append(hlContent, "")
-		for _, h := range info.highlights {
-			hlContent = append(hlContent, center("· "+h))
-			hlContent = append(hlContent, "")
-		}
-		hlContent = append(hlContent, center("━━━━━━━━━━━━━━━━━━━━"))
-		cards = append(cards, makeCard(hlContent))
-	}
+	for _, s := range doc.sections {
+		switch s.kind {
+		case sectionTitle:
+			content := titleLines(s.blocks[0], titleFont, width)
+			if meta := joinMeta(s.blocks[1], " · ", kindLicense); meta != "" {
+				content = append(content, line(kindMeta, "· "+meta+" ·"))
+			}
+			add(bordered(nil, content))
+
+		case sectionLead, sectionCast:
+			// Contributor cards
+			for _, b := range s.blocks {
+				c := b.person
+				header := []creditLine{line(kindSubheading, spiderTitle(b.rank, c.commits)), blank}
+				var content []creditLine
+				for _, l := range b.lines {
+					switch l.kind {
+					case kindIntro:
+						content = append(content, line(l.kind, "· INTRODUCING ·"))
+					case kindName:
+						content = append(content, line(l.kind, strings.ToUpper(l.text)), blank)
+					case kindSummary:
+						content = append(content, line(l.kind, fmt.Sprintf("%d webs spun", c.commits-c.coAuthored)))
+						if c.coAuthored > 0 {
+							content = append(content, line(l.kind, fmt.Sprintf("%d webs co-spun", c.coAuthored)))
+						}
+					case kindImpact:
+						content = append(content, line(kindDetail, l.text+" lines"))
+					default:
+						content = append(content, l)
+					}
+				}
+				add(bordered(header, content))
+			}
 
-	// Final card
-	totalCommits := 0
-	for _, c := range info.contributors {
-		totalCommits += c.commits
-	}
-	// Stats card
-	var statsContent []string
-	statsContent = append(statsContent, center("━━━━━━━━━━━━━━━━━━━━"))
-	statsContent = append(statsContent, "")
-	statsContent = append(statsContent, center(fmt.Sprintf("%d  C O M M I T S", totalCommits)))
-	statsContent = append(statsContent, "")
-	statsContent = append(statsContent, center(fmt.Sprintf("%d  C O N T R I B U T O R S", len(info.contributors))))
-	if info.stars > 0 {
-		statsContent = append(statsContent, "")
-		statsContent = append(statsContent, center(fmt.Sprintf("★  %d  S T A R S  ★", info.stars)))
-	}
-	if info.language != "" {
-		statsContent = append(statsContent, "")
-		statsContent = append(statsContent, center("Written in "+info.language))
-	}
-	if info.license != "" {
-		statsContent = append(statsContent, "")
-		statsContent = append(statsContent, center("Licensed under "+info.license))
+		case sectionDepartment, sectionEffects:
+			// Department cards from commit trailers, then automation
+			header := []creditLine{line(kindHeading, spacedCaps(s.heading)), blank}
+			var content []creditLine
+			for _, b := range s.blocks {
+				content = append(content, line(kindName, roleCreditLine(b.lines[0])))
+			}
+			add(bordered(header, content))
+
+		case sectionScenes:
+			// Notable commits card
+			header := []creditLine{line(kindHeading, "N O T A B L E   C O M M I T S"), blank}
+			var content []creditLine
+			for _, b := range s.blocks {
+				for _, l := range b.lines {
+					if l.kind == kindSubheading {
+						content = append(content, line(l.kind, strings.ToUpper(l.text)))
+					} else {
+						content = append(content, line(l.kind, "· "+sceneLine(l)))
+					}
+				}
+				content = append(content, blank)
+			}
+			add(bordered(header, content))
+
+		case sectionStats:
+			// the commit count is the webs the cast spun themselves
+			spun := 0
+			for _, c := range info.contributors {
+				spun += c.commits - c.coAuthored
+			}
+			var content []creditLine
+			for _, b := range s.blocks {
+				for j, l := range b.lines {
+					if len(content) > 0 && (j == 0 || l.kind == kindStat || l.kind == kindLicense) {
+						content = append(content, blank)
+					}
+					switch l.kind {
+					case kindStat:
+						n := l.count
+						if l.text == "COMMITS" {
+							n = spun
+						}
+						content = append(content, line(l.kind, fmt.Sprintf("%d  %s", n, spacedCaps(l.text))))
+					case kindStar:
+						content = append(content, line(l.kind, fmt.Sprintf("★  %d  S T A R S  ★", l.count)))
+					case kindLanguages:
+						content = append(content, line(kindFact, "Written in "+l.text))
+					case kindLicense:
+						content = append(content, line(kindFact, "Licensed under "+l.text))
+					default:
+						content = append(content, l)
+					}
+				}
+			}
+			add(bordered(nil, content))
+
+		case sectionEnd:
+			add(cardContent{body: []creditLine{
+				line(kindEnd, "With great power comes"),
+				line(kindEnd, "great responsibility"),
+			}})
+		}
 	}
-	statsContent = append(statsContent, "")
-	statsContent = append(statsContent, center("━━━━━━━━━━━━━━━━━━━━"))
-	cards = append(cards, makeCard(statsContent))
-
-	// Final card
-	var finalContent []string
-	finalContent = append(finalContent, "")
-	finalContent = append(finalContent, "")
-	finalContent = append(finalContent, center("With great power comes"))
-	finalContent = append(finalContent, center("great responsibility"))
-	finalContent = append(finalContent, "")
-	finalContent = append(finalContent, "")
-	cards = append(cards, makeCard(finalContent))
 
 	return cards
 }
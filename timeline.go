@@ -0,0 +1,139 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// timeline is the story arc of the credited history.
+type timeline struct {
+	first, last time.Time   // earliest and latest author dates
+	years       map[int]int // commits per author-date year
+	releases    int         // tags within the credited revisions
+}
+
+// addDate extends the timeline with a commit's author date. Log order is
+// not date order, so both ends are tracked as minimum and maximum.
+func (t *timeline) addDate(d time.Time) {
+	if t.first.IsZero() || d.Before(t.first) {
+		t.first = d
+	}
+	if d.After(t.last) {
+		t.last = d
+	}
+	if t.years == nil {
+		t.years = make(map[int]int)
+	}
+	t.years[d.Year()]++
+}
+
+// merge combines another repository's timeline into t.
+func (t *timeline) merge(o timeline) {
+	if !o.first.IsZero() && (t.first.IsZero() || o.first.Before(t.first)) {
+		t.first = o.first
+	}
+	if o.last.After(t.last) {
+		t.last = o.last
+	}
+	for y, n := range o.years {
+		if t.years == nil {
+			t.years = make(map[int]int)
+		}
+		t.years[y] += n
+	}
+	t.releases += o.releases
+}
+
+// busiestYear returns the year with the most commits, the earliest on ties.
+func (t timeline) busiestYear() (year, commits int) {
+	for y, n := range t.years {
+		if n > commits || (n == commits && y < year) {
+			year, commits = y, n
+		}
+	}
+	return year, commits
+}
+
+// releaseCount counts the tags reachable from the credited revision (HEAD
+// by default) but not from the excluded side of a range, so
+// "v1.2.0..v1.3.0" counts the releases it spans. With --since or --until
+// only tags on commits inside the window count.
+func releaseCount(ctx context.Context, dir string, opts repoOptions) (int, error) {
+	rev := opts.rev
+	if rev == "" {
+		rev = "HEAD"
+	}
+	out, err := runCommand(ctx, dir, "git", "rev-parse", rev)
+	if err != nil {
+		return 0, err
+	}
+	args := []string{"for-each-ref", "--format=%(objectname) %(*objectname)"}
+	for _, line := range strings.Fields(string(out)) {
+		if strings.HasPrefix(line, "^") {
+			args = append(args, "--no-merged="+line[1:])
+		} else {
+			args = append(args, "--merged="+line)
+		}
+	}
+	out, err = runCommand(ctx, dir, "git", append(args, "refs/tags")...)
+	if err != nil {
+		return 0, err
+	}
+	tags := strings.Split(strings.TrimSpace(string(out)), "\n")
+	if len(tags) == 1 && tags[0] == "" {
+		return 0, nil
+	}
+	if opts.since == "" && opts.until == "" {
+		return len(tags), nil
+	}
+
+	window := repoOptions{since: opts.since, until: opts.until, rev: opts.rev}
+	inWindow := make(map[string]bool)
+	err = streamLines(ctx, dir, func(hash string) {
+		inWindow[hash] = true
+	}, "git", append([]string{"rev-list"}, window.revisionArgs()...)...)
+	if err != nil {
+		return 0, err
+	}
+	n := 0
+	for _, tag := range tags {
+		// annotated tags peel to the commit in the second field
+		object, commit, _ := strings.Cut(tag, " ")
+		if commit == "" {
+			commit = object
+		}
+		if inWindow[commit] {
+			n++
+		}
+	}
+	return n, nil
+}
+
+// timelineLines renders the story arc for the stats section, e.g.
+// "Filmed between 2019 and 2026", "Across 42 releases" and
+// "Busiest year: 2023 (1,204 commits)".
+func timelineLines(t timeline) []string {
+	if t.first.IsZero() {
+		return nil
+	}
+	var lines []string
+	if from, to := t.first.Year(), t.last.Year(); from == to {
+		lines = append(lines, fmt.Sprintf("Filmed in %d", from))
+	} else {
+		lines = append(lines, fmt.Sprintf("Filmed between %d and %d", from, to))
+	}
+	switch t.releases {
+	case 0:
+	case 1:
+		lines = append(lines, "Across 1 release")
+	default:
+		lines = append(lines, fmt.Sprintf("Across %s releases", formatCount(t.releases)))
+	}
+	if len(t.years) > 1 {
+		year, commits := t.busiestYear()
+		lines = append(lines, fmt.Sprintf("Busiest year: %d (%s commits)", year, formatCount(commits)))
+	}
+	return lines
+}
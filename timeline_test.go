@@ -0,0 +1,121 @@
+package main
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestTimelineLines(t *testing.T) {
+	var tl timeline
+	for _, d := range []string{"2023-05-01", "2019-03-10", "2026-01-02", "2023-06-01", "2023-07-01"} {
+		when, _ := time.Parse("2006-01-02", d)
+		tl.addDate(when)
+	}
+	tl.releases = 42
+
+	got := strings.Join(timelineLines(tl), " | ")
+	want := "Filmed between 2019 and 2026 | Across 42 releases | Busiest year: 2023 (3 commits)"
+	if got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+
+	var single timeline
+	single.addDate(time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC))
+	single.releases = 1
+	if got := strings.Join(timelineLines(single), " | "); got != "Filmed in 2026 | Across 1 release" {
+		t.Errorf("single year: got %q", got)
+	}
+
+	if lines := timelineLines(timeline{}); lines != nil {
+		t.Errorf("empty timeline: got %q", lines)
+	}
+}
+
+func TestTimelineMerge(t *testing.T) {
+	var a, b timeline
+	a.addDate(time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC))
+	a.releases = 3
+	b.addDate(time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC))
+	b.addDate(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	b.releases = 2
+
+	var merged timeline
+	merged.merge(a)
+	merged.merge(b)
+	if merged.first.Year() != 2019 || merged.last.Year() != 2024 || merged.releases != 5 || len(merged.years) != 3 {
+		t.Errorf("unexpected merge: %+v", merged)
+	}
+}
+
+func TestGetRepoInfo_Timeline(t *testing.T) {
+	repoDir := setupTestRepo(t)
+	commitAt(t, repoDir, "2019-04-01T12:00:00", "feat: the beginning")
+	runInDir(t, repoDir, "git", "tag", "v1.0.0")
+	commitAt(t, repoDir, "2023-02-01T12:00:00", "feat: busy")
+	commitAt(t, repoDir, "2023-03-01T12:00:00", "fix: busier")
+	runInDir(t, repoDir, "git", "tag", "-a", "v2.0.0", "-m", "Second release")
+	commitAt(t, repoDir, "2024-01-01T12:00:00", "feat: unreleased")
+
+	info, err := getRepoInfo(context.Background(), repoDir, repoOptions{})
+	if err != nil {
+		t.Fatalf("getRepoInfo returned error: %v", err)
+	}
+	tl := info.timeline
+	if tl.first.Year() != 2019 || tl.releases != 2 {
+		t.Fatalf("unexpected timeline: %+v", tl)
+	}
+	if year, commits := tl.busiestYear(); year != 2023 || commits != 2 {
+		t.Fatalf("unexpected busiest year: %d (%d)", year, commits)
+	}
+
+	info, err = getRepoInfo(context.Background(), repoDir, repoOptions{rev: "v1.0.0..v2.0.0"})
+	if err != nil {
+		t.Fatalf("getRepoInfo returned error: %v", err)
+	}
+	if info.timeline.releases != 1 || info.timeline.first.Year() != 2023 {
+		t.Fatalf("expected one release in range, got %+v", info.timeline)
+	}
+
+	info, err = getRepoInfo(context.Background(), repoDir, repoOptions{since: "2023-01-01", until: "2023-12-31"})
+	if err != nil {
+		t.Fatalf("getRepoInfo returned error: %v", err)
+	}
+	if info.timeline.releases != 1 {
+		t.Fatalf("expected only the release tagged in 2023, got %d", info.timeline.releases)
+	}
+	info, err = getRepoInfo(context.Background(), repoDir, repoOptions{since: "2024"})
+	if err != nil {
+		t.Fatalf("getRepoInfo returned error: %v", err)
+	}
+	if info.timeline.releases != 0 {
+		t.Fatalf("expected no releases tagged in 2024, got %d", info.timeline.releases)
+	}
+}
+
+func TestBuildCredits_Timeline(t *testing.T) {
+	info := repoInfo{name: "test", totalCommits: 5, contributors: []contributor{{name: "Alice", commits: 5}}}
+	info.timeline.addDate(time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC))
+	info.timeline.addDate(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC))
+	info.timeline.releases = 42
+
+	for name, lines := range map[string][]string{
+		"default":   buildCredits(info, blockFont, 80),
+		"matrix":    cardLines(buildMatrixCards(info, blockFont, 80, 24)),
+		"spiderman": cardLines(buildSpidermanCards(info, blockFont, 80, 24)),
+	} {
+		joined := strings.Join(lines, "\n")
+		if !strings.Contains(joined, "Filmed between 2019 and 2026") || !strings.Contains(joined, "Across 42 releases") {
+			t.Errorf("%s theme is missing the timeline", name)
+		}
+	}
+}
+
+func cardLines(cards []matrixCard) []string {
+	var lines []string
+	for _, c := range cards {
+		lines = append(lines, c.lines...)
+	}
+	return lines
+}
@@ -40,16 +40,18 @@ type tickMsg struct{}
 
 type model struct {
 	// default theme
-	lines     []string
+	lines     []creditLine
 	offset    int
 	starField starField
 	webField  webField
 
 	// common
-	height int
-	width  int
-	done   bool
-	theme  string
+	height    int
+	width     int
+	done      bool
+	theme     string
+	info      repoInfo
+	titleFont *font
 
 	// matrix theme
 	cards      []matrixCard
@@ -61,6 +63,56 @@ type model struct {
 	resolveMap [][]bool // which cells have been resolved
 }
 
+// newModel lays the credits out for a terminal of the given size.
+func newModel(info repoInfo, theme string, titleFont *font, width, height int) model {
+	m := model{info: info, theme: theme, titleFont: titleFont, width: width, height: height, mState: mvsRain}
+	m.layout()
+	if theme == "matrix" || theme == "spiderman" {
+		m.initRain()
+	}
+	return m
+}
+
+// layout builds the credits for the current width and height, so cards
+// are paginated to fit and lines wrap to the screen.
+func (m *model) layout() {
+	switch m.theme {
+	case "matrix":
+		m.cards = buildMatrixCards(m.info, m.titleFont, m.width, m.height)
+	case "spiderman":
+		m.cards = buildSpidermanCards(m.info, m.titleFont, m.width, m.height)
+		m.webField = newWebField(m.width, m.height*len(m.cards))
+	default:
+		m.lines = layoutCredits(buildCreditsDoc(m.info), m.titleFont, m.width)
+		m.starField = newStarField(m.width, len(m.lines))
+		if m.offset > len(m.lines) {
+			m.offset = len(m.lines)
+		}
+	}
+}
+
+// resize lays the credits out again for a new terminal size. A card theme
+// shows its current card again, clamped to the new card count.
+func (m *model) resize(width, height int) {
+	if width == m.width && height == m.height {
+		return
+	}
+	m.width, m.height = width, height
+	m.layout()
+	if m.theme != "matrix" && m.theme != "spiderman" {
+		return
+	}
+	m.initRain()
+	if m.cardIdx >= len(m.cards) {
+		m.cardIdx = len(m.cards) - 1
+	}
+	if m.cardIdx < 0 {
+		m.cardIdx = 0
+	}
+	m.mState = mvsResolve
+	m.mFrame = 0
+}
+
 func (m *model) initRain() {
 	m.rainCols = make([]rainColumn, m.width)
 	m.rainGrid = make([][]rune, m.height)
@@ -160,8 +212,7 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 
 	case tea.WindowSizeMsg:
-		m.height = msg.Height
-		m.width = msg.Width
+		m.resize(msg.Width, msg.Height)
 		return m, nil
 
 	case tickMsg:
@@ -203,9 +254,9 @@ func (m model) updateMatrix() (tea.Model, tea.Cmd) {
 				if r < len(card.lines) {
 					line = card.lines[r]
 				}
-				runes := []rune(line)
-				for c := 0; c < len(runes) && c < m.width; c++ {
-					if runes[c] != ' ' && runes[c] != 0 && !m.resolveMap[r][c] {
+				cells := textCells(line)
+				for c := 0; c < len(cells) && c < m.width; c++ {
+					if cellHasText(cells[c]) && !m.resolveMap[r][c] {
 						if rand.Float64() < progress*0.15 {
 							m.resolveMap[r][c] = true
 						}
@@ -222,9 +273,9 @@ func (m model) updateMatrix() (tea.Model, tea.Cmd) {
 					if r < len(card.lines) {
 						line = card.lines[r]
 					}
-					runes := []rune(line)
-					for c := 0; c < len(runes) && c < m.width; c++ {
-						if runes[c] != ' ' && runes[c] != 0 {
+					cells := textCells(line)
+					for c := 0; c < len(cells) && c < m.width; c++ {
+						if cellHasText(cells[c]) {
 							m.resolveMap[r][c] = true
 						}
 					}
@@ -347,36 +398,35 @@ func (m model) viewMatrix() string {
 	var sb strings.Builder
 
 	for r := 0; r < m.height; r++ {
+		var cells []string
+		if m.cardIdx < len(m.cards) && r < len(card.lines) {
+			cells = textCells(card.lines[r])
+		}
 		for c := 0; c < m.width; c++ {
-			// check if this cell has text
-			isTextCell := false
-			var textRune rune
-			if m.cardIdx < len(m.cards) && r < len(card.lines) {
-				runes := []rune(card.lines[r])
-				if c < len(runes) && runes[c] != ' ' && runes[c] != 0 {
-					isTextCell = true
-					textRune = runes[c]
-				}
+			// check if this cell has text; a wide glyph covers this
+			// cell and the next, and is dropped if it would spill over
+			// the edge of the grid
+			cell := ""
+			if c < len(cells) {
+				cell = cells[c]
 			}
+			wide := c+1 < len(cells) && cells[c+1] == ""
+			isTextCell := cellHasText(cell) && (!wide || c+1 < m.width)
 
 			resolved := r < len(m.resolveMap) && c < len(m.resolveMap[r]) && m.resolveMap[r][c]
 
 			if isTextCell && resolved {
-				// determine line content for color
-				ch := string(textRune)
-				lineStr := ""
-				if r < len(card.lines) {
-					lineStr = strings.TrimSpace(card.lines[r])
+				// colour by the row's role
+				ch := cell
+				if wide {
+					c++
 				}
-				if strings.Contains(lineStr, "THE ") && !strings.Contains(lineStr, "WILL RETURN") && !strings.Contains(lineStr, "commits") {
+				switch card.kindAt(r) {
+				case kindTitle, kindSubheading, kindDivider:
 					sb.WriteString(goldText.Render(ch))
-				} else if strings.Contains(lineStr, "██") {
-					sb.WriteString(goldText.Render(ch))
-				} else if strings.Contains(lineStr, "\"") || strings.Contains(lineStr, "·") || strings.Contains(lineStr, "★") || strings.Contains(lineStr, "Forged") {
+				case kindTagline, kindQuote, kindMeta, kindDetail, kindScene, kindStar, kindFact:
 					sb.WriteString(cyanText.Render(ch))
-				} else if strings.Contains(lineStr, "━") {
-					sb.WriteString(goldText.Render(ch))
-				} else {
+				default:
 					sb.WriteString(whiteText.Render(ch))
 				}
 			} else if isTextCell && m.mState == mvsResolve {
@@ -571,7 +621,7 @@ func (m model) viewSpiderman() string {
 	for r := 0; r < m.height; r++ {
 		lineStr := ""
 		if m.cardIdx < len(m.cards) && r < len(card.lines) {
-			lineStr = card.lines[r]
+			lineStr = clipWidth(card.lines[r], m.width)
 		}
 
 		hasText := strings.TrimSpace(lineStr) != ""
@@ -579,18 +629,16 @@ func (m model) viewSpiderman() string {
 		if hasText {
 			// Check if most chars are resolved
 			resolvedCount := 0
-			runes := []rune(lineStr)
-			for c := 0; c < len(runes) && c < m.width; c++ {
+			cells := textCells(lineStr)
+			for c := 0; c < len(cells) && c < m.width; c++ {
 				if r < len(m.resolveMap) && c < len(m.resolveMap[r]) && m.resolveMap[r][c] {
 					resolvedCount++
 				}
 			}
-			resolved = resolvedCount > len(runes)/2
+			resolved = resolvedCount > len(cells)/2
 		}
 
 		if hasText && (m.mState == mvsShow || (m.mState == mvsResolve && resolved) || m.mState == mvsDissolve) {
-			trimmed := strings.TrimSpace(lineStr)
-
 			if glitchIntensity > 0 && rand.Float64() < glitchIntensity*0.5 {
 				// Full line glitch: RGB shift
 				redLine, blueLine := rgbShift(lineStr, rgbOffset+1)
@@ -604,19 +652,14 @@ func (m model) viewSpiderman() string {
 				sb.WriteString(white.Render(glitchLine(lineStr, glitchIntensity*0.4)))
 			} else {
 				// Clean render with color
-				if strings.Contains(trimmed, "█") || strings.Contains(trimmed, "▌") {
+				switch card.kindAt(r) {
+				case kindTitle, kindEnd, kindHeading, kindIntro, kindName, kindStat:
 					sb.WriteString(white.Render(lineStr))
-				} else if strings.Contains(trimmed, "★") {
+				case kindStar, kindMeta:
 					sb.WriteString(gold.Render(lineStr))
-				} else if strings.Contains(trimmed, "SPIDER") || strings.Contains(trimmed, "MILES") || strings.Contains(trimmed, "GWEN") {
+				case kindSubheading:
 					sb.WriteString(red.Render(lineStr))
-				} else if strings.Contains(trimmed, "great power") || strings.Contains(trimmed, "great responsibility") {
-					sb.WriteString(white.Render(lineStr))
-				} else if strings.Contains(trimmed, "━") {
-					sb.WriteString(blue.Render(lineStr))
-				} else if trimmed == strings.ToUpper(trimmed) && len(trimmed) > 2 {
-					sb.WriteString(white.Render(lineStr))
-				} else {
+				default:
 					sb.WriteString(blue.Render(lineStr))
 				}
 			}
@@ -668,9 +711,9 @@ func (m model) viewDefault() string {
 	}
 
 	for i := start; i < end && i < len(m.lines); i++ {
-		line := m.lines[i]
+		kind := m.lines[i].kind
+		line := clipWidth(m.lines[i].text, m.width)
 		screenIdx := i - start
-		trimmed := strings.TrimSpace(line)
 
 		fadeTop := 4
 		fadeBottom := 4
@@ -681,7 +724,7 @@ func (m model) viewDefault() string {
 		isVeryFaded := distFromTop < 2 || distFromBottom < 2
 
 		var styled string
-		if trimmed == "" {
+		if kind == kindBlank {
 			starLine := make([]rune, m.width)
 			for j := range starLine {
 				starLine[j] = ' '
@@ -697,81 +740,31 @@ func (m model) viewDefault() string {
 			} else {
 				styled = ""
 			}
-		} else if strings.Contains(trimmed, "██") {
-			if isVeryFaded {
-				styled = dimmer.Render(line)
-			} else if isFaded {
-				styled = dim.Render(line)
-			} else {
-				styled = title.Render(line)
-			}
-		} else if strings.Contains(trimmed, "━") {
-			if isFaded {
-				styled = dim.Render(line)
-			} else {
-				styled = accent.Render(line)
-			}
-		} else if strings.Contains(trimmed, "★") {
-			if isFaded {
-				styled = dim.Render(line)
-			} else {
-				styled = gold.Render(line)
-			}
-		} else if strings.HasPrefix(trimmed, "A   P R O") || strings.HasPrefix(trimmed, "S T A R") ||
-			strings.HasPrefix(trimmed, "N O T A B") {
-			if isVeryFaded {
-				styled = dimmer.Render(line)
-			} else if isFaded {
-				styled = dim.Render(line)
-			} else {
-				styled = bright.Render(line)
-			}
-		} else if strings.Contains(trimmed, "C O M M") || strings.Contains(trimmed, "C O N T R") ||
-			strings.Contains(trimmed, "S T A R G") {
-			if isFaded {
-				styled = dim.Render(line)
-			} else {
-				styled = silver.Render(line)
-			}
-		} else if strings.Contains(trimmed, "\"") {
-			if isFaded {
-				styled = dim.Render(line)
-			} else {
-				styled = accent.Render(line)
-			}
-		} else if strings.Contains(trimmed, "· ") && strings.HasSuffix(trimmed, " ·") {
-			if isFaded {
-				styled = dim.Render(line)
-			} else {
-				styled = scene.Render(line)
-			}
-		} else if strings.Contains(trimmed, "—") && strings.Contains(trimmed, "commits") {
-			if isFaded {
-				styled = dim.Render(line)
-			} else {
-				styled = accent.Render(line)
-			}
-		} else if strings.Contains(trimmed, "commits") && !strings.Contains(trimmed, "C O M") {
-			if isFaded {
-				styled = dim.Render(line)
-			} else {
-				styled = accent.Render(line)
-			}
-		} else if trimmed == strings.ToUpper(trimmed) && len(trimmed) > 2 && !strings.Contains(trimmed, " O ") {
-			if isVeryFaded {
-				styled = dimmer.Render(line)
-			} else if isFaded {
-				styled = dim.Render(line)
-			} else {
-				styled = contributor.Render(line)
-			}
 		} else {
-			if isVeryFaded {
+			// lines that stand out fade further near the edges
+			style, deepFade := silver, true
+			switch kind {
+			case kindTitle, kindEnd:
+				style = title
+			case kindHeading:
+				style = bright
+			case kindName, kindSubheading:
+				style = contributor
+			case kindDivider, kindQuote, kindSummary:
+				style, deepFade = accent, false
+			case kindStar:
+				style, deepFade = gold, false
+			case kindStat:
+				style, deepFade = silver, false
+			case kindScene:
+				style, deepFade = scene, false
+			}
+			if isVeryFaded && deepFade {
 				styled = dimmer.Render(line)
 			} else if isFaded {
 				styled = dim.Render(line)
 			} else {
-				styled = silver.Render(line)
+				styled = style.Render(line)
 			}
 		}
 
@@ -0,0 +1,246 @@
+package main
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/mattn/go-runewidth"
+	"github.com/rivo/uniseg"
+)
+
+// displayWidth returns how many terminal cells s occupies: East Asian wide
+// characters and most emoji take two, combining marks none.
+func displayWidth(s string) int {
+	return runewidth.StringWidth(s)
+}
+
+// clipWidth cuts s to at most width cells, so a line never wraps.
+func clipWidth(s string, width int) string {
+	if width <= 0 || displayWidth(s) <= width {
+		return s
+	}
+	return runewidth.Truncate(s, width, "")
+}
+
+// indicLinkers are the viramas that join the consonants around them into
+// one conjunct, as in the "प्र" of "प्रिया".
+var indicLinkers = []rune{'\u094d', '\u09cd', '\u0acd', '\u0b4d', '\u0c4d', '\u0d4d'}
+
+// graphemeClusters splits s into user-perceived characters. uniseg
+// predates Unicode 15.1's rule keeping Indic conjuncts together, so a
+// cluster ending in a linker absorbs the consonant cluster after it.
+func graphemeClusters(s string) []string {
+	var clusters []string
+	g := uniseg.NewGraphemes(s)
+	for g.Next() {
+		cluster := g.Str()
+		if n := len(clusters); n > 0 && linksConjunct(clusters[n-1], cluster) {
+			clusters[n-1] += cluster
+			continue
+		}
+		clusters = append(clusters, cluster)
+	}
+	return clusters
+}
+
+// linksConjunct reports whether prev ends in a linker, optionally followed
+// by a zero-width joiner, and next starts with a letter of the same script.
+func linksConjunct(prev, next string) bool {
+	prev = strings.TrimSuffix(prev, "\u200d")
+	linker, _ := utf8.DecodeLastRuneInString(prev)
+	first, _ := utf8.DecodeRuneInString(next)
+	for _, l := range indicLinkers {
+		if linker == l {
+			return unicode.IsLetter(first) && sameScript(linker, first)
+		}
+	}
+	return false
+}
+
+// sameScript reports whether a and b are in the same Unicode script.
+func sameScript(a, b rune) bool {
+	for _, script := range unicode.Scripts {
+		if unicode.Is(script, a) {
+			return unicode.Is(script, b)
+		}
+	}
+	return false
+}
+
+// textCells splits s into terminal cells, so grids can be indexed by
+// screen column. Each grapheme cluster is one glyph, measured the same way
+// as displayWidth, so emoji with skin tones, flags and ZWJ sequences stay
+// whole. A double-width glyph fills two cells, its text in the first and
+// "" in the second; a zero-width one joins the glyph before it.
+func textCells(s string) []string {
+	cells := make([]string, 0, len(s))
+	for _, cluster := range graphemeClusters(s) {
+		switch runewidth.StringWidth(cluster) {
+		case 0:
+			if i := lastGlyph(cells); i >= 0 {
+				cells[i] += cluster
+			}
+		case 2:
+			cells = append(cells, cluster, "")
+		default:
+			cells = append(cells, cluster)
+		}
+	}
+	return cells
+}
+
+// lastGlyph returns the index of the last cell that starts a glyph.
+func lastGlyph(cells []string) int {
+	for i := len(cells) - 1; i >= 0; i-- {
+		if cells[i] != "" {
+			return i
+		}
+	}
+	return -1
+}
+
+// cellHasText reports whether a cell from textCells draws something.
+func cellHasText(cell string) bool {
+	return cell != "" && cell != " "
+}
+
+// joinCells is the inverse of textCells.
+func joinCells(cells []string) string {
+	return strings.Join(cells, "")
+}
+
+// layoutMargin is the number of columns kept clear on each side when
+// fitting titles and wrapping text.
+const layoutMargin = 2
+
+// maxTitleLines caps how many lines a title may be split into before a
+// smaller font is tried.
+const maxTitleLines = 2
+
+// fitTitle draws title within width. It tries titleFont, first on one
+// line and then split at word boundaries, steps down to the small font the
+// same way, and finally falls back to spaced capitals, or plain capitals
+// wrapped at words when even those are too wide.
+// Lines of a split title are separated by an empty row.
+func fitTitle(title string, titleFont *font, width int) []string {
+	avail := width - 2*layoutMargin
+	fonts := []*font{titleFont}
+	if titleFont != smallFont {
+		fonts = append(fonts, smallFont)
+	}
+	for _, f := range fonts {
+		if !f.canRender(title) {
+			continue
+		}
+		if lines := splitTitle(title, f, avail); lines != nil {
+			var rows []string
+			for i, line := range lines {
+				if i > 0 {
+					rows = append(rows, "")
+				}
+				rows = append(rows, f.render(line)...)
+			}
+			return rows
+		}
+	}
+	if spaced := spacedCaps(title); displayWidth(spaced) <= avail {
+		return []string{spaced}
+	}
+	return wrapText(strings.ToUpper(title), avail)
+}
+
+// splitTitle breaks title into as few lines as fit avail columns when drawn
+// in f, at most maxTitleLines, or returns nil if it can't.
+func splitTitle(title string, f *font, avail int) []string {
+	fits := func(s string) bool {
+		return displayWidth(f.render(s)[0]) <= avail
+	}
+	if fits(title) {
+		return []string{title}
+	}
+	var lines []string
+	line := ""
+	for _, word := range titleWords(title) {
+		switch {
+		case line == "":
+			line = word
+		case fits(line + word):
+			line += word
+		default:
+			lines = append(lines, strings.TrimSpace(line))
+			line = strings.TrimLeft(word, " ")
+		}
+		if !fits(strings.TrimSpace(line)) {
+			return nil
+		}
+	}
+	lines = append(lines, strings.TrimSpace(line))
+	if len(lines) > maxTitleLines {
+		return nil
+	}
+	return lines
+}
+
+// titleWords splits a title where it may break: before spaces, and after
+// the hyphens, underscores, dots and slashes that separate words in repo
+// names, e.g. "k8s-" "tools".
+func titleWords(title string) []string {
+	var words []string
+	start := 0
+	for i, r := range title {
+		switch r {
+		case ' ':
+			if i > start {
+				words = append(words, title[start:i])
+				start = i
+			}
+		case '-', '_', '.', '/':
+			words = append(words, title[start:i+1])
+			start = i + 1
+		}
+	}
+	if start < len(title) {
+		words = append(words, title[start:])
+	}
+	return words
+}
+
+// wrapText breaks s into lines of at most width cells at spaces. Words
+// longer than a line are cut.
+func wrapText(s string, width int) []string {
+	if width < 1 || displayWidth(s) <= width {
+		return []string{s}
+	}
+	var lines []string
+	line := ""
+	for _, word := range strings.Fields(s) {
+		for displayWidth(word) > width {
+			if line != "" {
+				lines = append(lines, line)
+				line = ""
+			}
+			head := runewidth.Truncate(word, width, "")
+			if head == "" {
+				_, size := utf8.DecodeRuneInString(word)
+				head = word[:size] // a wide glyph in a one-cell line
+			}
+			lines = append(lines, head)
+			word = word[len(head):]
+		}
+		switch {
+		case word == "":
+		case line == "":
+			line = word
+		case displayWidth(line)+1+displayWidth(word) <= width:
+			line += " " + word
+		default:
+			lines = append(lines, line)
+			line = word
+		}
+	}
+	if line != "" {
+		lines = append(lines, line)
+	}
+	return lines
+}
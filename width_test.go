@@ -0,0 +1,189 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+func TestCenterText_DisplayWidth(t *testing.T) {
+	for _, s := range []string{"ALICE", "김민준", "山田太郎", "🎬 José", "éclair"} {
+		got := centerText(s, 20)
+		left := (20 - displayWidth(s)) / 2
+		if pad := len(got) - len(strings.TrimLeft(got, " ")); pad != left {
+			t.Errorf("centerText(%q) padded by %d, want %d", s, pad, left)
+		}
+	}
+}
+
+func TestTextCells(t *testing.T) {
+	got := textCells("a김é🎬")
+	want := []string{"a", "김", "", "é", "🎬", ""}
+	if strings.Join(got, "|") != strings.Join(want, "|") {
+		t.Errorf("textCells = %q, want %q", got, want)
+	}
+	if joinCells(got) != "a김é🎬" {
+		t.Errorf("joinCells did not round-trip: %q", joinCells(got))
+	}
+
+	// emoji modifier, flag and ZWJ sequences and Indic conjuncts are one
+	// glyph each, and the grid is as wide as displayWidth says
+	for _, s := range []string{"\U0001f44d\U0001f3fd Kim", "\U0001f1f0\U0001f1f7 Kim", "\U0001f469\u200d\U0001f4bb Kim", "प्रिया", "山田 Kim"} {
+		cells := textCells(s)
+		if len(cells) != displayWidth(s) {
+			t.Errorf("textCells(%q) has %d cells, displayWidth is %d", s, len(cells), displayWidth(s))
+		}
+		if joinCells(cells) != s {
+			t.Errorf("joinCells did not round-trip %q: %q", s, joinCells(cells))
+		}
+	}
+}
+
+func TestSpacedCaps(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{"Reviewed by", "R E V I E W E D   B Y"},
+		{"山田太郎", "山 田 太 郎"},
+		{"प्रिया", "प्रि या"},
+		{"👩‍💻 Kim", "👩‍💻   K I M"},
+		{"Jose\u0301", "J O S E\u0301"},
+	}
+	for _, tt := range tests {
+		got := spacedCaps(tt.in)
+		if got != tt.want {
+			t.Errorf("spacedCaps(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+		// every cluster keeps its width, plus one cell per added space
+		if want := displayWidth(strings.ToUpper(tt.in)) + len(graphemeClusters(tt.in)) - 1; displayWidth(got) != want {
+			t.Errorf("spacedCaps(%q) is %d cells wide, want %d", tt.in, displayWidth(got), want)
+		}
+	}
+}
+
+func TestClipWidth(t *testing.T) {
+	if got := clipWidth("山田太郎", 5); got != "山田" {
+		t.Errorf("clipWidth = %q, want %q", got, "山田")
+	}
+	if got := clipWidth("short", 80); got != "short" {
+		t.Errorf("clipWidth changed a short line: %q", got)
+	}
+}
+
+func TestGlitchEffects_KeepWidth(t *testing.T) {
+	line := centerText("김민준 · 山田太郎 🎬", 40)
+	w := displayWidth(line)
+	for i := 0; i < 50; i++ {
+		if got := displayWidth(glitchLine(line, 0.5)); got != w {
+			t.Fatalf("glitchLine changed width from %d to %d", w, got)
+		}
+		for _, offset := range []int{1, 2, 3} {
+			red, blue := rgbShift(line, offset)
+			if displayWidth(red) != w || displayWidth(blue) != w {
+				t.Fatalf("rgbShift(%d) changed width: %d, %d, want %d", offset, displayWidth(red), displayWidth(blue), w)
+			}
+		}
+	}
+}
+
+func TestViewMatrix_WideNamesStayInGrid(t *testing.T) {
+	info := repoInfo{
+		name:         "test",
+		totalCommits: 3,
+		contributors: []contributor{{name: "김민준", commits: 2}, {name: "山田太郎🎬", commits: 1}},
+	}
+	const width, height = 41, 24
+	for _, theme := range []string{"matrix", "spiderman"} {
+		m := model{width: width, height: height, theme: theme, mState: mvsShow}
+		if theme == "matrix" {
+			m.cards = buildMatrixCards(info, blockFont, width, height)
+		} else {
+			m.cards = buildSpidermanCards(info, blockFont, width, height)
+		}
+		m.initRain()
+		for idx := range m.cards {
+			m.cardIdx = idx
+			for r := range m.resolveMap {
+				for c := range m.resolveMap[r] {
+					m.resolveMap[r][c] = true
+				}
+			}
+			for i, row := range strings.Split(m.View(), "\n") {
+				if w := lipgloss.Width(row); w > width {
+					t.Errorf("%s card %d row %d is %d cells wide, want at most %d", theme, idx, i, w, width)
+				}
+			}
+		}
+	}
+}
+
+func TestFitTitle(t *testing.T) {
+	const width = 80
+	avail := width - 2*layoutMargin
+	height := func(rows []string) int { return len(rows) }
+
+	if rows := fitTitle("gitcredits", blockFont, width); strings.Join(rows, "\n") != strings.Join(blockFont.render("gitcredits"), "\n") {
+		t.Errorf("a short title should be drawn as is")
+	}
+	if rows := fitTitle("gitcredits-operator", blockFont, width); height(rows) != 2*blockFont.height+1 {
+		t.Errorf("expected the title split over two block lines, got %d rows", height(rows))
+	}
+	if rows := fitTitle("kubernetes-operator-toolkit", blockFont, width); rows[0] != smallFont.render("kubernetes-")[0] {
+		t.Errorf("expected the small font split at the hyphen, got %q", rows[0])
+	}
+	if rows := fitTitle("日本語", blockFont, width); len(rows) != 1 || rows[0] != "日 本 語" {
+		t.Errorf("titles the fonts can't draw should fall back to spaced caps, got %q", rows)
+	}
+	long := strings.Repeat("verylongname", 8)
+	for _, title := range []string{"infrastructure-tools", "kubernetes-operator-toolkit", long} {
+		for _, row := range fitTitle(title, blockFont, width) {
+			if w := displayWidth(row); w > avail {
+				t.Errorf("%q: row is %d cells wide, want at most %d", title, w, avail)
+			}
+		}
+	}
+}
+
+func TestWrapText(t *testing.T) {
+	got := wrapText("a very long description that runs past the edge", 16)
+	want := []string{"a very long", "description that", "runs past the", "edge"}
+	if strings.Join(got, "|") != strings.Join(want, "|") {
+		t.Errorf("wrapText = %q, want %q", got, want)
+	}
+	if got := wrapText("supercalifragilistic", 8); strings.Join(got, "|") != "supercal|ifragili|stic" {
+		t.Errorf("long words should be cut, got %q", got)
+	}
+	if got := wrapText("김민준 山田太郎", 5); strings.Join(got, "|") != "김민|준|山田|太郎" {
+		t.Errorf("wide text should wrap by cells, got %q", got)
+	}
+	if got := wrapText("短", 1); len(got) != 1 || got[0] != "短" {
+		t.Errorf("a wide glyph in a one-cell line should not loop, got %q", got)
+	}
+}
+
+func TestCredits_FitWidth(t *testing.T) {
+	info := repoInfo{
+		name:         "kubernetes-operator-toolkit",
+		description:  "A toolkit for building Kubernetes operators that keeps going well past the edge of any reasonable terminal",
+		totalCommits: 2,
+		contributors: []contributor{{name: "Alice", commits: 2}},
+		highlights:   []highlight{{kind: "feat", description: "support reconciling custom resources across every namespace in the cluster at once", scope: "controller"}},
+	}
+	const width = 60
+	lines := map[string][]string{
+		"default":   buildCredits(info, blockFont, width),
+		"matrix":    cardLines(buildMatrixCards(info, blockFont, width, 40)),
+		"spiderman": cardLines(buildSpidermanCards(info, blockFont, width, 40)),
+	}
+	for theme, ls := range lines {
+		for _, l := range ls {
+			if w := displayWidth(strings.TrimRight(l, " ")); w > width-layoutMargin {
+				t.Errorf("%s: line is %d cells wide: %q", theme, w, l)
+			}
+		}
+		if !strings.Contains(strings.Join(ls, "\n"), "edge of any reasonable") {
+			t.Errorf("%s: the description should be wrapped, not cut", theme)
+		}
+	}
+}
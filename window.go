@@ -0,0 +1,101 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// calendar date layouts accepted by --since / --until; anything else is
+// handed to git as-is ("3 months ago", "last monday", ...).
+var windowDateLayouts = []string{"2006-01-02", "2006-01", "2006"}
+
+// parseWindowDate parses a calendar date. The second result reports
+// whether s was a calendar date at all.
+func parseWindowDate(s string) (time.Time, string, bool) {
+	s = strings.TrimSpace(s)
+	for _, layout := range windowDateLayouts {
+		if t, err := time.ParseInLocation(layout, s, time.Local); err == nil {
+			return t, layout, true
+		}
+	}
+	return time.Time{}, "", false
+}
+
+// windowBound turns a --since / --until value into a git date argument.
+// Bare calendar dates are expanded to cover whole days, months or years,
+// because git otherwise fills in the current time of day.
+func windowBound(s string, end bool) string {
+	t, layout, ok := parseWindowDate(s)
+	if !ok {
+		return s
+	}
+	if end {
+		switch layout {
+		case "2006":
+			t = t.AddDate(1, 0, 0)
+		case "2006-01":
+			t = t.AddDate(0, 1, 0)
+		default:
+			t = t.AddDate(0, 0, 1)
+		}
+		t = t.Add(-time.Second)
+	}
+	return t.Format("2006-01-02 15:04:05")
+}
+
+// windowLabel describes a time window for the title card, preferring
+// calendar names like "Q3 2026" or "2025" when the bounds line up.
+func windowLabel(since, until string) string {
+	since, until = strings.TrimSpace(since), strings.TrimSpace(until)
+	if since == "" && until == "" {
+		return ""
+	}
+
+	start, startLayout, startOK := parseWindowDate(since)
+	end, endLayout, endOK := parseWindowDate(until)
+	if startOK && endOK {
+		// make end exclusive
+		switch endLayout {
+		case "2006":
+			end = end.AddDate(1, 0, 0)
+		case "2006-01":
+			end = end.AddDate(0, 1, 0)
+		default:
+			end = end.AddDate(0, 0, 1)
+		}
+		if start.Day() == 1 {
+			switch {
+			case start.Month() == time.January && end.Equal(start.AddDate(1, 0, 0)):
+				return fmt.Sprintf("%d", start.Year())
+			case (start.Month()-1)%3 == 0 && end.Equal(start.AddDate(0, 3, 0)):
+				return fmt.Sprintf("Q%d %d", (start.Month()-1)/3+1, start.Year())
+			case end.Equal(start.AddDate(0, 1, 0)):
+				return start.Format("January 2006")
+			}
+		}
+		return formatWindowDate(since, start, startLayout) + " – " + formatWindowDate(until, end.AddDate(0, 0, -1), endLayout)
+	}
+
+	switch {
+	case since != "" && until != "":
+		return fmt.Sprintf("%s – %s", formatWindowDate(since, start, startLayout), formatWindowDate(until, end, endLayout))
+	case since != "":
+		return "Since " + formatWindowDate(since, start, startLayout)
+	default:
+		return "Until " + formatWindowDate(until, end, endLayout)
+	}
+}
+
+func formatWindowDate(raw string, t time.Time, layout string) string {
+	switch layout {
+	case "":
+		return raw
+	case "2006":
+		return t.Format("2006")
+	case "2006-01":
+		return t.Format("Jan 2006")
+	default:
+		return t.Format("Jan 2, 2006")
+	}
+}
@@ -0,0 +1,36 @@
+package main
+
+import "testing"
+
+func TestWindowLabel(t *testing.T) {
+	tests := []struct {
+		since, until string
+		want         string
+	}{
+		{"", "", ""},
+		{"2026-07-01", "2026-09-30", "Q3 2026"},
+		{"2026-01-01", "2026-12-31", "2026"},
+		{"2025", "2025", "2025"},
+		{"2026-03", "2026-03", "March 2026"},
+		{"2026-03-05", "2026-04-10", "Mar 5, 2026 – Apr 10, 2026"},
+		{"3 months ago", "", "Since 3 months ago"},
+		{"", "2026-06-30", "Until Jun 30, 2026"},
+	}
+	for _, tt := range tests {
+		if got := windowLabel(tt.since, tt.until); got != tt.want {
+			t.Errorf("windowLabel(%q, %q) = %q, want %q", tt.since, tt.until, got, tt.want)
+		}
+	}
+}
+
+func TestWindowBound(t *testing.T) {
+	if got := windowBound("2026-09-30", true); got != "2026-09-30 23:59:59" {
+		t.Errorf("end of day bound = %q", got)
+	}
+	if got := windowBound("2026-07", false); got != "2026-07-01 00:00:00" {
+		t.Errorf("start of month bound = %q", got)
+	}
+	if got := windowBound("2 weeks ago", false); got != "2 weeks ago" {
+		t.Errorf("relative bound should pass through, got %q", got)
+	}
+}